@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	adk "github.com/inference-gateway/adk/types"
+)
+
+// fetchExtendedAgentCard retrieves the authenticated extended agent card when the
+// public card advertises support for it
+func fetchExtendedAgentCard(ctx context.Context, publicCard *adk.AgentCard) (*adk.AgentCard, error) {
+	if publicCard.SupportsExtendedAgentCard == nil || !*publicCard.SupportsExtendedAgentCard {
+		return nil, fmt.Errorf("the agent card does not advertise an authenticated extended card")
+	}
+
+	resp, err := a2aClient.GetAuthenticatedExtendedCard(ctx, adk.GetAuthenticatedExtendedCardParams{})
+	if err != nil {
+		return nil, handleA2AError(err, "agent/getAuthenticatedExtendedCard")
+	}
+
+	var extendedCard adk.AgentCard
+	if err := decodeResult(resp.Result, &extendedCard); err != nil {
+		return nil, err
+	}
+
+	return &extendedCard, nil
+}
+
+// diffAgentCards reports the skills, capabilities and other top-level fields that
+// differ between the public and the extended agent card
+func diffAgentCards(publicCard, extendedCard *adk.AgentCard) (map[string]any, error) {
+	public, err := cardFields(publicCard)
+	if err != nil {
+		return nil, err
+	}
+	extended, err := cardFields(extendedCard)
+	if err != nil {
+		return nil, err
+	}
+
+	added, removed, changed := diffSkills(publicCard.Skills, extendedCard.Skills)
+	capabilities := diffFields(asMap(public["capabilities"]), asMap(extended["capabilities"]))
+
+	delete(public, "skills")
+	delete(extended, "skills")
+	delete(public, "capabilities")
+	delete(extended, "capabilities")
+	fields := diffFields(public, extended)
+
+	diff := map[string]any{
+		"identical": len(added) == 0 && len(removed) == 0 && len(changed) == 0 && len(capabilities) == 0 && len(fields) == 0,
+		"skills": map[string]any{
+			"added":   added,
+			"removed": removed,
+			"changed": changed,
+		},
+		"capabilities": capabilities,
+		"fields":       fields,
+	}
+
+	return diff, nil
+}
+
+// diffSkills compares skills by ID and returns the IDs only present in the extended
+// card, only present in the public card, and present in both with different content
+func diffSkills(publicSkills, extendedSkills []adk.AgentSkill) (added, removed, changed []string) {
+	publicByID := make(map[string]adk.AgentSkill, len(publicSkills))
+	for _, skill := range publicSkills {
+		publicByID[skill.ID] = skill
+	}
+	extendedByID := make(map[string]adk.AgentSkill, len(extendedSkills))
+	for _, skill := range extendedSkills {
+		extendedByID[skill.ID] = skill
+	}
+
+	added, removed, changed = []string{}, []string{}, []string{}
+	for id, skill := range extendedByID {
+		publicSkill, ok := publicByID[id]
+		switch {
+		case !ok:
+			added = append(added, id)
+		case !reflect.DeepEqual(publicSkill, skill):
+			changed = append(changed, id)
+		}
+	}
+	for id := range publicByID {
+		if _, ok := extendedByID[id]; !ok {
+			removed = append(removed, id)
+		}
+	}
+
+	sort.Strings(added)
+	sort.Strings(removed)
+	sort.Strings(changed)
+	return added, removed, changed
+}
+
+// diffFields returns the keys whose values differ between two JSON objects, with
+// the public and extended value of each
+func diffFields(public, extended map[string]any) map[string]any {
+	diff := map[string]any{}
+	keys := map[string]bool{}
+	for key := range public {
+		keys[key] = true
+	}
+	for key := range extended {
+		keys[key] = true
+	}
+
+	for key := range keys {
+		if reflect.DeepEqual(public[key], extended[key]) {
+			continue
+		}
+		diff[key] = map[string]any{
+			"public":   public[key],
+			"extended": extended[key],
+		}
+	}
+	return diff
+}
+
+// cardFields converts an agent card into its generic JSON object form
+func cardFields(card *adk.AgentCard) (map[string]any, error) {
+	cardBytes, err := json.Marshal(card)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal agent card: %w", err)
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(cardBytes, &fields); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal agent card: %w", err)
+	}
+	return fields, nil
+}
+
+// asMap returns value as a JSON object, or an empty object if it is not one
+func asMap(value any) map[string]any {
+	if m, ok := value.(map[string]any); ok {
+		return m
+	}
+	return map[string]any{}
+}
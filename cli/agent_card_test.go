@@ -0,0 +1,363 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	adk "github.com/inference-gateway/adk/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+func testAgentCards() (*adk.AgentCard, *adk.AgentCard) {
+	supported := true
+	streaming := true
+	pushNotifications := true
+
+	public := &adk.AgentCard{
+		Name:                      "Helper",
+		Description:               "Public helper",
+		Version:                   "1.0.0",
+		SupportsExtendedAgentCard: &supported,
+		Capabilities:              adk.AgentCapabilities{Streaming: &streaming},
+		Skills: []adk.AgentSkill{
+			{ID: "search", Name: "Search", Description: "Search the web"},
+			{ID: "summarize", Name: "Summarize", Description: "Summarize text"},
+		},
+	}
+
+	extended := &adk.AgentCard{
+		Name:                      "Helper",
+		Description:               "Helper with private skills",
+		Version:                   "1.0.0",
+		SupportsExtendedAgentCard: &supported,
+		Capabilities:              adk.AgentCapabilities{Streaming: &streaming, PushNotifications: &pushNotifications},
+		Skills: []adk.AgentSkill{
+			{ID: "search", Name: "Search", Description: "Search the web and the intranet"},
+			{ID: "billing", Name: "Billing", Description: "Look up invoices"},
+		},
+	}
+
+	return public, extended
+}
+
+func TestDiffAgentCards(t *testing.T) {
+	public, extended := testAgentCards()
+
+	diff, err := diffAgentCards(public, extended)
+	if err != nil {
+		t.Fatalf("diffAgentCards failed: %v", err)
+	}
+
+	if diff["identical"] != false {
+		t.Error("Expected cards to differ")
+	}
+
+	skills := diff["skills"].(map[string]any)
+	if !reflect.DeepEqual(skills["added"], []string{"billing"}) {
+		t.Errorf("Expected billing to be added, got %v", skills["added"])
+	}
+	if !reflect.DeepEqual(skills["removed"], []string{"summarize"}) {
+		t.Errorf("Expected summarize to be removed, got %v", skills["removed"])
+	}
+	if !reflect.DeepEqual(skills["changed"], []string{"search"}) {
+		t.Errorf("Expected search to be changed, got %v", skills["changed"])
+	}
+
+	capabilities := diff["capabilities"].(map[string]any)
+	if _, ok := capabilities["pushNotifications"]; !ok || len(capabilities) != 1 {
+		t.Errorf("Expected only pushNotifications capability to differ, got %v", capabilities)
+	}
+
+	fields := diff["fields"].(map[string]any)
+	if _, ok := fields["description"]; !ok || len(fields) != 1 {
+		t.Errorf("Expected only description to differ, got %v", fields)
+	}
+
+	same, err := diffAgentCards(public, public)
+	if err != nil {
+		t.Fatalf("diffAgentCards failed: %v", err)
+	}
+	if same["identical"] != true {
+		t.Errorf("Expected identical cards, got %v", same)
+	}
+}
+
+func TestAgentCardCmd_Extended(t *testing.T) {
+	originalClient := a2aClient
+	originalLogger := logger
+	logger = zap.NewNop()
+	defer func() {
+		a2aClient = originalClient
+		logger = originalLogger
+	}()
+
+	public, extended := testAgentCards()
+	unsupported := *public
+	unsupported.SupportsExtendedAgentCard = nil
+
+	tests := []struct {
+		name        string
+		publicCard  *adk.AgentCard
+		diff        bool
+		expectError bool
+	}{
+		{
+			name:       "Extended card",
+			publicCard: public,
+		},
+		{
+			name:       "Diff",
+			publicCard: public,
+			diff:       true,
+		},
+		{
+			name:        "Extended card not supported",
+			publicCard:  &unsupported,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			extendedCalls := 0
+			a2aClient = &mockA2AClient{
+				getAgentCardFunc: func(ctx context.Context) (*adk.AgentCard, error) {
+					return tt.publicCard, nil
+				},
+				getExtendedCardFunc: func(ctx context.Context, params adk.GetAuthenticatedExtendedCardParams) (*adk.JSONRPCSuccessResponse, error) {
+					extendedCalls++
+					return &adk.JSONRPCSuccessResponse{Result: extended}, nil
+				},
+			}
+
+			viper.Set("output", "json")
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			cmd := &cobra.Command{}
+			cmd.Flags().Bool("extended", true, "")
+			cmd.Flags().Bool("diff", tt.diff, "")
+
+			err := agentCardCmd.RunE(cmd, []string{})
+
+			_ = w.Close()
+			os.Stdout = oldStdout
+			var buf bytes.Buffer
+			_, _ = buf.ReadFrom(r)
+
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("Expected an error")
+				}
+				if extendedCalls != 0 {
+					t.Error("Expected the extended card not to be requested")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if extendedCalls != 1 {
+				t.Errorf("Expected 1 extended card request, got %d", extendedCalls)
+			}
+
+			var output map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &output); err != nil {
+				t.Fatalf("Output is not valid JSON: %v\n%s", err, buf.String())
+			}
+			if tt.diff {
+				if _, ok := output["skills"]; !ok {
+					t.Errorf("Expected diff output, got %v", output)
+				}
+				return
+			}
+			if output["description"] != extended.Description {
+				t.Errorf("Expected extended card, got %v", output)
+			}
+		})
+	}
+}
+
+func TestValidateAgentCard(t *testing.T) {
+	validCard := `{
+		"name": "Helper",
+		"description": "Helps",
+		"version": "1.0.0",
+		"protocolVersion": "0.3.0",
+		"url": "https://agent.example.com/a2a",
+		"capabilities": {"streaming": true},
+		"defaultInputModes": ["text/plain"],
+		"defaultOutputModes": ["text/plain", "application/json"],
+		"skills": [{"id": "search", "name": "Search", "description": "Search", "tags": ["web"]}]
+	}`
+
+	tests := []struct {
+		name          string
+		card          string
+		expectedRules []string
+		expectErrors  bool
+	}{
+		{
+			name: "Valid card",
+			card: validCard,
+		},
+		{
+			name:          "Missing url and invalid MIME type",
+			card:          `{"name":"Helper","description":"Helps","version":"1.0.0","protocolVersion":"0.3.0","capabilities":{},"defaultInputModes":["text"],"defaultOutputModes":["text/plain"],"skills":[{"id":"s","name":"S","description":"d","tags":["t"]}]}`,
+			expectedRules: []string{"url", "media-type"},
+			expectErrors:  true,
+		},
+		{
+			name:          "Empty and duplicate skill IDs",
+			card:          `{"name":"Helper","description":"Helps","version":"1.0.0","protocolVersion":"0.3.0","url":"https://a.example.com","capabilities":{},"defaultInputModes":["text/plain"],"defaultOutputModes":["text/plain"],"skills":[{"id":"","name":"A","description":"d","tags":["t"]},{"id":"b","name":"B","description":"d","tags":["t"]},{"id":"b","name":"B2","description":"d","tags":["t"]}]}`,
+			expectedRules: []string{"skill-id", "skill-id"},
+			expectErrors:  true,
+		},
+		{
+			name:          "Missing required fields",
+			card:          `{"name":"Helper","url":"https://a.example.com"}`,
+			expectedRules: []string{"required-field", "input-modes"},
+			expectErrors:  true,
+		},
+		{
+			name:          "Unknown and renamed fields are warnings",
+			card:          strings.Replace(validCard, `"name": "Helper",`, `"name": "Helper", "supportsAuthenticatedExtendedCard": true, "extra": 1,`, 1),
+			expectedRules: []string{"renamed-field", "unknown-field"},
+		},
+		{
+			name:          "Wrong field type",
+			card:          strings.Replace(validCard, `"skills": [`, `"skills": "none", "old": [`, 1),
+			expectedRules: []string{"schema"},
+			expectErrors:  true,
+		},
+		{
+			name:          "Undefined security scheme",
+			card:          strings.Replace(validCard, `"name": "Helper",`, `"name": "Helper", "security": [{"schemes": {"oauth": {}}}],`, 1),
+			expectedRules: []string{"security"},
+			expectErrors:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := validateAgentCard([]byte(tt.card))
+
+			rules := map[string]int{}
+			hasErrors := false
+			for _, finding := range findings {
+				rules[finding.Rule]++
+				if finding.Severity == severityError {
+					hasErrors = true
+				}
+			}
+
+			if hasErrors != tt.expectErrors {
+				t.Errorf("Expected errors=%v, got findings: %+v", tt.expectErrors, findings)
+			}
+			expected := map[string]int{}
+			for _, rule := range tt.expectedRules {
+				expected[rule]++
+			}
+			for rule, count := range expected {
+				if rules[rule] < count {
+					t.Errorf("Expected %d %q finding(s), got findings: %+v", count, rule, findings)
+				}
+			}
+		})
+	}
+}
+
+func TestReadAgentCardFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "card.yaml")
+	content := "name: Helper\ndefaultInputModes:\n  - text/plain\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write card: %v", err)
+	}
+
+	cardJSON, err := readAgentCardFile(path)
+	if err != nil {
+		t.Fatalf("readAgentCardFile failed: %v", err)
+	}
+
+	var card map[string]any
+	if err := json.Unmarshal(cardJSON, &card); err != nil {
+		t.Fatalf("Expected JSON output, got %s", cardJSON)
+	}
+	if card["name"] != "Helper" {
+		t.Errorf("Expected name Helper, got %v", card["name"])
+	}
+}
+
+func TestAgentCardValidateCmd_Live(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/agent-card.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"Broken","description":"d","protocolVersion":"0.3.0","url":"http://agent/a2a",
+			"capabilities":"none","defaultInputModes":["text/plain"],"defaultOutputModes":["text/plain"],"skills":[],
+			"supportsAuthenticatedExtendedCard":true}`))
+	}))
+	defer server.Close()
+
+	originalClient, originalHTTPClient := a2aClient, a2aHTTPClient
+	defer func() {
+		a2aClient, a2aHTTPClient = originalClient, originalHTTPClient
+		viper.Set("server-url", "")
+		viper.Set("output", "")
+	}()
+	a2aClient = &mockA2AClient{}
+	a2aHTTPClient = http.DefaultClient
+	viper.Set("server-url", server.URL)
+	viper.Set("output", "json")
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("file", "", "")
+	err := agentCardValidateCmd.RunE(cmd, []string{})
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r)
+
+	if err == nil {
+		t.Fatal("Expected the validation to fail")
+	}
+	var output struct {
+		Source   string        `json:"source"`
+		Findings []lintFinding `json:"findings"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &output); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, buf.String())
+	}
+	if output.Source != server.URL {
+		t.Errorf("Expected the server URL as source, got %q", output.Source)
+	}
+
+	rules := map[string]bool{}
+	for _, finding := range output.Findings {
+		rules[finding.Rule+" "+finding.Path] = true
+	}
+	for _, expected := range []string{"required-field version", "renamed-field supportsAuthenticatedExtendedCard", "schema "} {
+		if !rules[expected] {
+			t.Errorf("Expected a %q finding from the served document, got %+v", expected, output.Findings)
+		}
+	}
+}
@@ -0,0 +1,380 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	cobra "github.com/spf13/cobra"
+	viper "github.com/spf13/viper"
+	yaml "gopkg.in/yaml.v3"
+
+	adk "github.com/inference-gateway/adk/types"
+)
+
+// Severities of agent card lint findings
+const (
+	severityError   = "error"
+	severityWarning = "warning"
+	severityInfo    = "info"
+)
+
+// requiredCardFields are the agent card fields the A2A schema marks as required
+var requiredCardFields = []string{
+	"name",
+	"description",
+	"version",
+	"protocolVersion",
+	"capabilities",
+	"defaultInputModes",
+	"defaultOutputModes",
+	"skills",
+}
+
+// renamedCardFields maps field names from older protocol versions to their current name
+var renamedCardFields = map[string]string{
+	"supportsAuthenticatedExtendedCard": "supportsExtendedAgentCard",
+}
+
+// lintFinding is a single problem reported by the agent card validator
+type lintFinding struct {
+	Severity string `json:"severity" yaml:"severity"`
+	Rule     string `json:"rule" yaml:"rule"`
+	Path     string `json:"path,omitempty" yaml:"path,omitempty"`
+	Message  string `json:"message" yaml:"message"`
+}
+
+var agentCardValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate an agent card against the A2A schema and lint rules",
+	Long: `Checks the agent card served by the A2A server, or a local JSON/YAML file given
+with --file, against the A2A schema and a set of semantic lint rules.
+
+Findings are reported with a severity of error, warning or info. The command
+exits with a non-zero status when any error is found, so it can gate CI.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, _ := cmd.Flags().GetString("file")
+
+		var (
+			cardJSON []byte
+			source   string
+			err      error
+		)
+
+		if file != "" {
+			source = file
+			cardJSON, err = readAgentCardFile(file)
+			if err != nil {
+				return err
+			}
+		} else {
+			ensureA2AClient()
+
+			source = viper.GetString("server-url")
+			cardJSON, err = fetchAgentCardJSON(context.Background(), a2aHTTPClient, source)
+			if err != nil {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("failed to fetch agent card: %w", err)
+			}
+		}
+
+		findings := validateAgentCard(cardJSON)
+		counts := map[string]int{severityError: 0, severityWarning: 0, severityInfo: 0}
+		for _, finding := range findings {
+			counts[finding.Severity]++
+		}
+
+		output := map[string]any{
+			"source":   source,
+			"valid":    counts[severityError] == 0,
+			"errors":   counts[severityError],
+			"warnings": counts[severityWarning],
+			"findings": findings,
+		}
+
+		if err := printFormatted(output); err != nil {
+			return err
+		}
+
+		if counts[severityError] > 0 {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("agent card validation failed with %d error(s)", counts[severityError])
+		}
+
+		return nil
+	},
+}
+
+// fetchAgentCardJSON returns the agent card document exactly as served at
+// /.well-known/agent-card.json, so that it can be validated without being decoded first
+func fetchAgentCardJSON(ctx context.Context, httpClient *http.Client, serverURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(serverURL, "/")+"/.well-known/agent-card.json", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read agent card: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return body, nil
+}
+
+// readAgentCardFile reads an agent card from a JSON or YAML file ("-" for stdin) and returns it as JSON
+func readAgentCardFile(path string) ([]byte, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read agent card: %w", err)
+	}
+
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".json" || json.Valid(data) {
+		return data, nil
+	}
+
+	var card any
+	if err := yaml.Unmarshal(data, &card); err != nil {
+		return nil, fmt.Errorf("failed to parse agent card %s as JSON or YAML: %w", path, err)
+	}
+
+	cardJSON, err := json.Marshal(card)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert agent card %s to JSON: %w", path, err)
+	}
+	return cardJSON, nil
+}
+
+// validateAgentCard checks an agent card document against the schema and the lint rules
+func validateAgentCard(cardJSON []byte) []lintFinding {
+	var findings []lintFinding
+	add := func(severity, rule, path, format string, args ...any) {
+		findings = append(findings, lintFinding{
+			Severity: severity,
+			Rule:     rule,
+			Path:     path,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(cardJSON, &raw); err != nil {
+		add(severityError, "schema", "", "agent card is not a JSON object: %v", err)
+		return findings
+	}
+
+	for _, field := range requiredCardFields {
+		if _, ok := raw[field]; !ok {
+			add(severityError, "required-field", field, "required field %q is missing", field)
+		}
+	}
+
+	knownFields := jsonFieldNames(reflect.TypeOf(adk.AgentCard{}))
+	for _, field := range sortedKeys(raw) {
+		if knownFields[field] {
+			continue
+		}
+		if renamed, ok := renamedCardFields[field]; ok {
+			add(severityWarning, "renamed-field", field, "field %q was renamed to %q", field, renamed)
+			continue
+		}
+		add(severityWarning, "unknown-field", field, "field %q is not part of the agent card schema", field)
+	}
+
+	var card adk.AgentCard
+	if err := json.Unmarshal(cardJSON, &card); err != nil {
+		add(severityError, "schema", "", "agent card does not match the schema: %v", err)
+		return findings
+	}
+
+	if strings.TrimSpace(card.Name) == "" {
+		add(severityError, "name", "name", "agent name must not be empty")
+	}
+	if strings.TrimSpace(card.Description) == "" {
+		add(severityWarning, "description", "description", "agent description is empty")
+	}
+	if strings.TrimSpace(card.Version) == "" {
+		add(severityError, "version", "version", "agent version must not be empty")
+	}
+	if strings.TrimSpace(card.ProtocolVersion) == "" {
+		add(severityWarning, "protocol-version", "protocolVersion", "protocol version is empty")
+	}
+
+	switch {
+	case card.URL != nil:
+		if err := validateEndpointURL(*card.URL); err != nil {
+			add(severityError, "url", "url", "%v", err)
+		}
+	case len(card.SupportedInterfaces) == 0:
+		add(severityError, "url", "url", "agent card has no url and no supportedInterfaces, clients cannot reach the agent")
+	}
+	for i, iface := range card.SupportedInterfaces {
+		if err := validateEndpointURL(iface.URL); err != nil {
+			add(severityError, "url", fmt.Sprintf("supportedInterfaces[%d].url", i), "%v", err)
+		}
+	}
+	for i, iface := range card.AdditionalInterfaces {
+		if err := validateEndpointURL(iface.URL); err != nil {
+			add(severityError, "url", fmt.Sprintf("additionalInterfaces[%d].url", i), "%v", err)
+		}
+	}
+
+	if len(card.DefaultInputModes) == 0 {
+		add(severityError, "input-modes", "defaultInputModes", "at least one default input mode is required")
+	}
+	if len(card.DefaultOutputModes) == 0 {
+		add(severityError, "output-modes", "defaultOutputModes", "at least one default output mode is required")
+	}
+	for _, mode := range invalidMediaTypes(card.DefaultInputModes) {
+		add(severityError, "media-type", "defaultInputModes", "%q is not a valid MIME type", mode)
+	}
+	for _, mode := range invalidMediaTypes(card.DefaultOutputModes) {
+		add(severityError, "media-type", "defaultOutputModes", "%q is not a valid MIME type", mode)
+	}
+
+	if len(card.Skills) == 0 {
+		add(severityWarning, "skills", "skills", "agent card does not declare any skills")
+	}
+	seenSkills := map[string]bool{}
+	for i, skill := range card.Skills {
+		path := fmt.Sprintf("skills[%d]", i)
+		switch {
+		case strings.TrimSpace(skill.ID) == "":
+			add(severityError, "skill-id", path+".id", "skill ID must not be empty")
+		case seenSkills[skill.ID]:
+			add(severityError, "skill-id", path+".id", "duplicate skill ID %q", skill.ID)
+		}
+		seenSkills[skill.ID] = true
+
+		if strings.TrimSpace(skill.Name) == "" {
+			add(severityError, "skill-name", path+".name", "skill name must not be empty")
+		}
+		if strings.TrimSpace(skill.Description) == "" {
+			add(severityWarning, "skill-description", path+".description", "skill description is empty")
+		}
+		if len(skill.Tags) == 0 {
+			add(severityInfo, "skill-tags", path+".tags", "skill has no tags")
+		}
+		for _, mode := range invalidMediaTypes(skill.InputModes) {
+			add(severityError, "media-type", path+".inputModes", "%q is not a valid MIME type", mode)
+		}
+		for _, mode := range invalidMediaTypes(skill.OutputModes) {
+			add(severityError, "media-type", path+".outputModes", "%q is not a valid MIME type", mode)
+		}
+		for _, requirement := range skill.Security {
+			for _, name := range undefinedSchemes(requirement, card.SecuritySchemes) {
+				add(severityError, "security", path+".security", "security requirement references undefined scheme %q", name)
+			}
+		}
+	}
+
+	usedSchemes := map[string]bool{}
+	for _, requirement := range card.Security {
+		for name := range requirement.Schemes {
+			usedSchemes[name] = true
+		}
+		for _, name := range undefinedSchemes(requirement, card.SecuritySchemes) {
+			add(severityError, "security", "security", "security requirement references undefined scheme %q", name)
+		}
+	}
+	for _, name := range sortedKeys(card.SecuritySchemes) {
+		if !usedSchemes[name] {
+			add(severityInfo, "security", "securitySchemes."+name, "security scheme %q is not required by the card", name)
+		}
+	}
+
+	if card.SupportsExtendedAgentCard != nil && *card.SupportsExtendedAgentCard && len(card.SecuritySchemes) == 0 {
+		add(severityWarning, "capabilities", "supportsExtendedAgentCard",
+			"the card advertises an authenticated extended card but declares no security schemes")
+	}
+	if card.Capabilities.PushNotifications != nil && *card.Capabilities.PushNotifications &&
+		(card.Capabilities.Streaming == nil || !*card.Capabilities.Streaming) {
+		add(severityInfo, "capabilities", "capabilities",
+			"push notifications are supported but streaming is not, clients will have to poll or use webhooks")
+	}
+
+	return findings
+}
+
+// validateEndpointURL checks that an agent endpoint is an absolute http(s) URL
+func validateEndpointURL(rawURL string) error {
+	if strings.TrimSpace(rawURL) == "" {
+		return fmt.Errorf("url must not be empty")
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("%q is not a valid URL: %v", rawURL, err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" || parsed.Host == "" {
+		return fmt.Errorf("%q must be an absolute http or https URL", rawURL)
+	}
+	return nil
+}
+
+// invalidMediaTypes returns the entries that are not valid type/subtype MIME types
+func invalidMediaTypes(modes []string) []string {
+	var invalid []string
+	for _, mode := range modes {
+		mediaType, _, err := mime.ParseMediaType(mode)
+		if err != nil {
+			invalid = append(invalid, mode)
+			continue
+		}
+		mainType, subType, ok := strings.Cut(mediaType, "/")
+		if !ok || mainType == "" || subType == "" {
+			invalid = append(invalid, mode)
+		}
+	}
+	return invalid
+}
+
+// undefinedSchemes returns the scheme names a security requirement references
+// that are not declared in the card's security schemes
+func undefinedSchemes(requirement adk.Security, schemes map[string]adk.SecurityScheme) []string {
+	var undefined []string
+	for name := range requirement.Schemes {
+		if _, ok := schemes[name]; !ok {
+			undefined = append(undefined, name)
+		}
+	}
+	sort.Strings(undefined)
+	return undefined
+}
+
+// jsonFieldNames returns the JSON field names of a struct type
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
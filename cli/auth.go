@@ -0,0 +1,177 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	viper "github.com/spf13/viper"
+	zap "go.uber.org/zap"
+	zapcore "go.uber.org/zap/zapcore"
+)
+
+// defaultAPIKeyHeader is the header an API key is sent in unless --api-key-header is set
+const defaultAPIKeyHeader = "X-API-Key"
+
+// redactedValue replaces secrets in log output
+const redactedValue = "[REDACTED]"
+
+// authTransport attaches credentials and custom headers to every outgoing request
+type authTransport struct {
+	base    http.RoundTripper
+	headers http.Header
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	clone := req.Clone(req.Context())
+	for key, values := range t.headers {
+		clone.Header[key] = append([]string(nil), values...)
+	}
+	return t.base.RoundTrip(clone)
+}
+
+// requestHeaders collects the headers configured through the token, api-key,
+// api-key-header, header and headers settings
+func requestHeaders() (http.Header, error) {
+	headers := http.Header{}
+
+	for key, value := range viper.GetStringMapString("headers") {
+		headers.Set(key, value)
+	}
+
+	for _, header := range viper.GetStringSlice("header") {
+		key, value, ok := strings.Cut(header, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid header %q, expected key=value", header)
+		}
+		headers.Set(key, strings.TrimSpace(value))
+	}
+
+	if apiKey := viper.GetString("api-key"); apiKey != "" {
+		headerName := viper.GetString("api-key-header")
+		if headerName == "" {
+			headerName = defaultAPIKeyHeader
+		}
+		headers.Set(headerName, apiKey)
+	}
+
+	if token := viper.GetString("token"); token != "" {
+		headers.Set("Authorization", "Bearer "+token)
+	}
+
+	return headers, nil
+}
+
+// isSensitiveHeader reports whether a header is likely to carry a credential
+func isSensitiveHeader(name string) bool {
+	lower := strings.ToLower(name)
+	if lower == "authorization" || lower == "proxy-authorization" || lower == "cookie" {
+		return true
+	}
+	if apiKeyHeader := viper.GetString("api-key-header"); apiKeyHeader != "" && strings.EqualFold(name, apiKeyHeader) {
+		return true
+	}
+	for _, marker := range []string{"token", "secret", "key", "password", "auth"} {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactHeaders returns the headers as a flat map with credential values masked
+func redactHeaders(headers http.Header) map[string]string {
+	redacted := make(map[string]string, len(headers))
+	for key, values := range headers {
+		if isSensitiveHeader(key) {
+			redacted[key] = redactedValue
+			continue
+		}
+		redacted[key] = strings.Join(values, ", ")
+	}
+	return redacted
+}
+
+// headerSecrets returns the credential values contained in the headers
+func headerSecrets(headers http.Header) []string {
+	var secrets []string
+	for key, values := range headers {
+		if !isSensitiveHeader(key) {
+			continue
+		}
+		for _, value := range values {
+			if value == "" {
+				continue
+			}
+			secrets = append(secrets, value)
+			// Also mask the bare credential of "<scheme> <credential>" values
+			if _, credential, ok := strings.Cut(value, " "); ok && credential != "" {
+				secrets = append(secrets, credential)
+			}
+		}
+	}
+	// Replace longer secrets first so a prefix never leaves part of a secret behind
+	sort.Slice(secrets, func(i, j int) bool { return len(secrets[i]) > len(secrets[j]) })
+	return secrets
+}
+
+// withSecretRedaction wraps the logger so the given secrets never appear in log output
+func withSecretRedaction(base *zap.Logger, secrets []string) *zap.Logger {
+	if len(secrets) == 0 {
+		return base
+	}
+	return base.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &redactingCore{Core: core, secrets: secrets}
+	}))
+}
+
+// redactingCore is a zapcore.Core that masks secrets in messages and string fields
+type redactingCore struct {
+	zapcore.Core
+	secrets []string
+}
+
+func (c *redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactingCore{Core: c.Core.With(c.redactFields(fields)), secrets: c.secrets}
+}
+
+func (c *redactingCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *redactingCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	entry.Message = c.redact(entry.Message)
+	return c.Core.Write(entry, c.redactFields(fields))
+}
+
+func (c *redactingCore) redact(value string) string {
+	for _, secret := range c.secrets {
+		value = strings.ReplaceAll(value, secret, redactedValue)
+	}
+	return value
+}
+
+func (c *redactingCore) redactFields(fields []zapcore.Field) []zapcore.Field {
+	redacted := make([]zapcore.Field, len(fields))
+	for i, field := range fields {
+		switch field.Type {
+		case zapcore.StringType:
+			field.String = c.redact(field.String)
+		case zapcore.ErrorType:
+			if err, ok := field.Interface.(error); ok {
+				field = zap.String(field.Key, c.redact(err.Error()))
+			}
+		case zapcore.StringerType:
+			if stringer, ok := field.Interface.(fmt.Stringer); ok {
+				field = zap.String(field.Key, c.redact(stringer.String()))
+			}
+		}
+		redacted[i] = field
+	}
+	return redacted
+}
@@ -0,0 +1,176 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	client "github.com/inference-gateway/adk/client"
+	adk "github.com/inference-gateway/adk/types"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// resetAuthSettings clears every authentication related viper key
+func resetAuthSettings() {
+	for _, key := range []string{"token", "api-key", "api-key-header", "header", "headers"} {
+		viper.Set(key, nil)
+	}
+}
+
+func TestRequestHeaders(t *testing.T) {
+	tests := []struct {
+		name        string
+		settings    map[string]any
+		expected    map[string]string
+		expectError bool
+	}{
+		{
+			name:     "No credentials",
+			settings: map[string]any{},
+			expected: map[string]string{},
+		},
+		{
+			name:     "Bearer token",
+			settings: map[string]any{"token": "abc"},
+			expected: map[string]string{"Authorization": "Bearer abc"},
+		},
+		{
+			name:     "API key with default header",
+			settings: map[string]any{"api-key": "key-1"},
+			expected: map[string]string{"X-Api-Key": "key-1"},
+		},
+		{
+			name:     "API key with custom header",
+			settings: map[string]any{"api-key": "key-1", "api-key-header": "X-Agent-Key"},
+			expected: map[string]string{"X-Agent-Key": "key-1"},
+		},
+		{
+			name: "Custom headers from flags and config",
+			settings: map[string]any{
+				"header":  []string{"X-Tenant=acme", "X-Trace = on"},
+				"headers": map[string]any{"X-Env": "staging"},
+			},
+			expected: map[string]string{"X-Tenant": "acme", "X-Trace": "on", "X-Env": "staging"},
+		},
+		{
+			name:        "Malformed header",
+			settings:    map[string]any{"header": []string{"no-separator"}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetAuthSettings()
+			defer resetAuthSettings()
+			for k, v := range tt.settings {
+				viper.Set(k, v)
+			}
+
+			headers, err := requestHeaders()
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(headers) != len(tt.expected) {
+				t.Errorf("Expected %d headers, got %d: %v", len(tt.expected), len(headers), headers)
+			}
+			for k, v := range tt.expected {
+				if got := headers.Get(k); got != v {
+					t.Errorf("Expected header %s=%q, got %q", k, v, got)
+				}
+			}
+		})
+	}
+}
+
+func TestAuthTransport_AppliesToAllRequests(t *testing.T) {
+	var seen []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Clone())
+		switch r.URL.Path {
+		case "/.well-known/agent-card.json":
+			_, _ = w.Write([]byte(`{"name":"secured","capabilities":{},"skills":[]}`))
+		default:
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"id":"task-1","contextId":"ctx-1","status":{"state":"TASK_STATE_COMPLETED"}}}`))
+		}
+	}))
+	defer server.Close()
+
+	headers := http.Header{}
+	headers.Set("Authorization", "Bearer abc")
+	headers.Set("X-Tenant", "acme")
+
+	httpClient, err := newHTTPClient(5*time.Second, headers)
+	if err != nil {
+		t.Fatalf("newHTTPClient failed: %v", err)
+	}
+
+	a2a := client.NewClient(server.URL)
+	a2a.SetHTTPClient(httpClient)
+
+	if _, err := a2a.GetAgentCard(context.Background()); err != nil {
+		t.Fatalf("GetAgentCard failed: %v", err)
+	}
+	if _, err := a2a.GetTask(context.Background(), adk.TaskQueryParams{ID: "task-1"}); err != nil {
+		t.Fatalf("GetTask failed: %v", err)
+	}
+
+	if len(seen) != 2 {
+		t.Fatalf("Expected 2 requests, got %d", len(seen))
+	}
+	for i, h := range seen {
+		if h.Get("Authorization") != "Bearer abc" {
+			t.Errorf("Request %d missing Authorization header", i)
+		}
+		if h.Get("X-Tenant") != "acme" {
+			t.Errorf("Request %d missing X-Tenant header", i)
+		}
+	}
+}
+
+func TestWithSecretRedaction(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+
+	headers := http.Header{}
+	headers.Set("Authorization", "Bearer super-secret-token")
+	headers.Set("X-Tenant", "acme")
+
+	redacted := withSecretRedaction(zap.New(core), headerSecrets(headers))
+	redacted.Debug("sending Bearer super-secret-token",
+		zap.String("header", "super-secret-token"),
+		zap.Error(errors.New("server echoed super-secret-token")),
+		zap.Any("headers", redactHeaders(headers)),
+	)
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 log entry, got %d", len(entries))
+	}
+
+	entry := entries[0]
+	if strings.Contains(entry.Message, "super-secret-token") {
+		t.Errorf("Message was not redacted: %s", entry.Message)
+	}
+	for key, value := range entry.ContextMap() {
+		if strings.Contains(fmt.Sprint(value), "super-secret-token") {
+			t.Errorf("Field %s was not redacted: %v", key, value)
+		}
+	}
+	if !strings.Contains(fmt.Sprint(entry.ContextMap()["headers"]), "acme") {
+		t.Error("Expected non-sensitive header values to be kept")
+	}
+}
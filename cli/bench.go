@@ -0,0 +1,409 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	uuid "github.com/google/uuid"
+	cobra "github.com/spf13/cobra"
+	viper "github.com/spf13/viper"
+
+	client "github.com/inference-gateway/adk/client"
+	adk "github.com/inference-gateway/adk/types"
+)
+
+// Error classes of failed benchmark requests that did not return a JSON-RPC error
+const (
+	benchErrorTimeout       = "timeout"
+	benchErrorTransport     = "transport"
+	benchErrorStreamError   = "stream-error-event"
+	benchErrorNoFinalStatus = "no-final-status"
+)
+
+// benchOptions configures a benchmark run
+type benchOptions struct {
+	Message     string
+	ContextID   string
+	Stream      bool
+	Concurrency int
+	Rate        float64
+	Duration    time.Duration
+	Requests    int
+}
+
+// benchSample is the outcome of a single benchmark request
+type benchSample struct {
+	Latency     time.Duration
+	FirstEvent  time.Duration
+	FinalStatus time.Duration
+	Error       string
+}
+
+// latencyStats summarizes a latency distribution in milliseconds
+type latencyStats struct {
+	Count int     `json:"count" yaml:"count"`
+	Min   float64 `json:"min_ms" yaml:"min_ms"`
+	Mean  float64 `json:"mean_ms" yaml:"mean_ms"`
+	P50   float64 `json:"p50_ms" yaml:"p50_ms"`
+	P90   float64 `json:"p90_ms" yaml:"p90_ms"`
+	P95   float64 `json:"p95_ms" yaml:"p95_ms"`
+	P99   float64 `json:"p99_ms" yaml:"p99_ms"`
+	Max   float64 `json:"max_ms" yaml:"max_ms"`
+}
+
+// benchReport is the outcome of a benchmark run
+type benchReport struct {
+	ServerURL         string         `json:"server_url" yaml:"server_url"`
+	Method            string         `json:"method" yaml:"method"`
+	Concurrency       int            `json:"concurrency" yaml:"concurrency"`
+	TargetRate        float64        `json:"target_rate,omitempty" yaml:"target_rate,omitempty"`
+	Duration          string         `json:"duration" yaml:"duration"`
+	Requests          int            `json:"requests" yaml:"requests"`
+	Succeeded         int            `json:"succeeded" yaml:"succeeded"`
+	Failed            int            `json:"failed" yaml:"failed"`
+	Throughput        float64        `json:"throughput_rps" yaml:"throughput_rps"`
+	Errors            map[string]int `json:"errors,omitempty" yaml:"errors,omitempty"`
+	Latency           *latencyStats  `json:"latency,omitempty" yaml:"latency,omitempty"`
+	TimeToFirstEvent  *latencyStats  `json:"time_to_first_event,omitempty" yaml:"time_to_first_event,omitempty"`
+	TimeToFinalStatus *latencyStats  `json:"time_to_final_status,omitempty" yaml:"time_to_final_status,omitempty"`
+}
+
+// runBench sends requests from opts.Concurrency workers until opts.Duration
+// elapses, opts.Requests have been sent or ctx is done. With a target rate
+// requests are started at that rate, otherwise each worker sends its next
+// request as soon as the previous one finishes. Requests still in flight when
+// the duration elapses are allowed to complete.
+func runBench(ctx context.Context, a2a client.A2AClient, opts benchOptions) benchReport {
+	dispatchCtx, cancel := context.WithTimeout(ctx, opts.Duration)
+	defer cancel()
+
+	jobs := make(chan struct{})
+	go func() {
+		defer close(jobs)
+
+		var tick <-chan time.Time
+		if opts.Rate > 0 {
+			ticker := time.NewTicker(benchInterval(opts.Rate))
+			defer ticker.Stop()
+			tick = ticker.C
+		}
+
+		for sent := 0; opts.Requests == 0 || sent < opts.Requests; sent++ {
+			if tick != nil && sent > 0 {
+				select {
+				case <-tick:
+				case <-dispatchCtx.Done():
+					return
+				}
+			}
+			select {
+			case jobs <- struct{}{}:
+			case <-dispatchCtx.Done():
+				return
+			}
+		}
+	}()
+
+	var (
+		mu      sync.Mutex
+		samples []benchSample
+		wg      sync.WaitGroup
+	)
+
+	start := time.Now()
+	for range opts.Concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range jobs {
+				params := buildMessageSendParams(uuid.NewString(), opts.Message, opts.ContextID, "")
+
+				var sample benchSample
+				if opts.Stream {
+					sample = benchStream(ctx, a2a, params)
+				} else {
+					sample = benchSend(ctx, a2a, params)
+				}
+
+				mu.Lock()
+				samples = append(samples, sample)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return summarizeBench(samples, time.Since(start), opts)
+}
+
+// benchSend sends a single message/send request
+func benchSend(ctx context.Context, a2a client.A2AClient, params adk.MessageSendParams) benchSample {
+	start := time.Now()
+	resp, err := a2a.SendTask(ctx, params)
+	sample := benchSample{Latency: time.Since(start)}
+	if err != nil {
+		sample.Error = benchErrorClass(err)
+		return sample
+	}
+
+	if task, err := taskFromResult(resp.Result); err == nil {
+		switch task.Status.State {
+		case adk.TaskStateFailed, adk.TaskStateRejected:
+			sample.Error = "task-" + humanState(task.Status.State)
+		}
+	}
+	return sample
+}
+
+// benchStream sends a single message/stream request and reads the stream to the end
+func benchStream(ctx context.Context, a2a client.A2AClient, params adk.MessageSendParams) benchSample {
+	start := time.Now()
+	events, err := a2a.SendTaskStreaming(ctx, params)
+	if err != nil {
+		return benchSample{Latency: time.Since(start), Error: benchErrorClass(err)}
+	}
+
+	var sample benchSample
+	for resp := range events {
+		if sample.FirstEvent == 0 {
+			sample.FirstEvent = time.Since(start)
+		}
+		if resp.Result == nil {
+			sample.Error = benchErrorStreamError
+			continue
+		}
+
+		eventJSON, kind, err := classifyStreamEvent(resp.Result)
+		if err != nil || kind != eventKindStatusUpdate {
+			continue
+		}
+		var status adk.TaskStatusUpdateEvent
+		if err := json.Unmarshal(eventJSON, &status); err == nil && status.Final && sample.FinalStatus == 0 {
+			sample.FinalStatus = time.Since(start)
+			if status.Status.State == adk.TaskStateFailed || status.Status.State == adk.TaskStateRejected {
+				sample.Error = "task-" + humanState(status.Status.State)
+			}
+		}
+	}
+	sample.Latency = time.Since(start)
+
+	if sample.Error == "" && sample.FinalStatus == 0 {
+		if ctx.Err() != nil {
+			sample.Error = benchErrorTimeout
+		} else {
+			sample.Error = benchErrorNoFinalStatus
+		}
+	}
+	return sample
+}
+
+// benchInterval returns the time between requests started at the given rate per second
+func benchInterval(rate float64) time.Duration {
+	return time.Duration(float64(time.Second) / rate)
+}
+
+// newBenchClient returns a client for the configured server that does not retry
+// failed requests, so that every failure is counted once and its latency is not
+// inflated by retry delays
+func newBenchClient() client.A2AClient {
+	config := client.DefaultConfig(viper.GetString("server-url"))
+	config.Timeout = viper.GetDuration("timeout")
+	config.Logger = logger
+	config.MaxRetries = 0
+
+	benchClient := client.NewClientWithConfig(config)
+	benchClient.SetHTTPClient(a2aHTTPClient)
+	return benchClient
+}
+
+// benchErrorClass groups a request error by JSON-RPC error code, HTTP status or failure kind
+func benchErrorClass(err error) string {
+	if rpcErr := jsonRPCErrorFrom(err); rpcErr != nil {
+		return "jsonrpc:" + strconv.Itoa(rpcErr.Code)
+	}
+	if match := httpStatusErrorPattern.FindStringSubmatch(err.Error()); match != nil {
+		return "http:" + match[1]
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) || os.IsTimeout(err) {
+		return benchErrorTimeout
+	}
+	return benchErrorTransport
+}
+
+// summarizeBench aggregates the samples of a run into a report
+func summarizeBench(samples []benchSample, elapsed time.Duration, opts benchOptions) benchReport {
+	report := benchReport{
+		ServerURL:   viper.GetString("server-url"),
+		Method:      "message/send",
+		Concurrency: opts.Concurrency,
+		TargetRate:  opts.Rate,
+		Duration:    elapsed.Round(time.Millisecond).String(),
+		Requests:    len(samples),
+		Errors:      map[string]int{},
+	}
+	if opts.Stream {
+		report.Method = "message/stream"
+	}
+
+	var latencies, firstEvents, finalStatuses []time.Duration
+	for _, sample := range samples {
+		if sample.Error != "" {
+			report.Failed++
+			report.Errors[sample.Error]++
+		} else {
+			report.Succeeded++
+			latencies = append(latencies, sample.Latency)
+		}
+		if sample.FirstEvent > 0 {
+			firstEvents = append(firstEvents, sample.FirstEvent)
+		}
+		if sample.FinalStatus > 0 {
+			finalStatuses = append(finalStatuses, sample.FinalStatus)
+		}
+	}
+
+	if elapsed > 0 {
+		report.Throughput = math.Round(float64(report.Succeeded)/elapsed.Seconds()*100) / 100
+	}
+	report.Latency = latencyStatsOf(latencies)
+	if opts.Stream {
+		report.TimeToFirstEvent = latencyStatsOf(firstEvents)
+		report.TimeToFinalStatus = latencyStatsOf(finalStatuses)
+	}
+
+	return report
+}
+
+// latencyStatsOf computes the distribution of a set of latencies, or nil when there are none
+func latencyStatsOf(latencies []time.Duration) *latencyStats {
+	if len(latencies) == 0 {
+		return nil
+	}
+
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, latency := range sorted {
+		total += latency
+	}
+
+	return &latencyStats{
+		Count: len(sorted),
+		Min:   durationMs(sorted[0]),
+		Mean:  durationMs(total / time.Duration(len(sorted))),
+		P50:   durationMs(percentile(sorted, 50)),
+		P90:   durationMs(percentile(sorted, 90)),
+		P95:   durationMs(percentile(sorted, 95)),
+		P99:   durationMs(percentile(sorted, 99)),
+		Max:   durationMs(sorted[len(sorted)-1]),
+	}
+}
+
+// percentile returns the nearest-rank percentile of sorted latencies
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// durationMs converts a duration to milliseconds rounded to two decimals
+func durationMs(d time.Duration) float64 {
+	return math.Round(float64(d)/float64(time.Millisecond)*100) / 100
+}
+
+// benchTable lists the latency distributions of a report
+func benchTable(report benchReport) tableView {
+	view := tableView{Headers: []string{"METRIC", "COUNT", "MIN", "MEAN", "P50", "P90", "P95", "P99", "MAX"}}
+
+	rows := []struct {
+		name  string
+		stats *latencyStats
+	}{
+		{"latency", report.Latency},
+		{"time-to-first-event", report.TimeToFirstEvent},
+		{"time-to-final-status", report.TimeToFinalStatus},
+	}
+	for _, row := range rows {
+		if row.stats == nil {
+			continue
+		}
+		s := row.stats
+		view.Rows = append(view.Rows, []string{row.name, strconv.Itoa(s.Count), formatMs(s.Min), formatMs(s.Mean), formatMs(s.P50), formatMs(s.P90), formatMs(s.P95), formatMs(s.P99), formatMs(s.Max)})
+	}
+
+	return view
+}
+
+// formatMs formats a millisecond value for table output
+func formatMs(ms float64) string {
+	return strconv.FormatFloat(ms, 'f', 1, 64) + "ms"
+}
+
+var benchCmd = &cobra.Command{
+	Use:   "bench [message]",
+	Short: "Benchmark the A2A server under concurrent load",
+	Long: `Sends the message to the A2A server from --concurrency parallel workers for
+--duration (or until --requests have been sent) and reports throughput, failed
+requests grouped by JSON-RPC error code or HTTP status, and latency percentiles.
+
+With --stream the requests use message/stream and the report also includes the
+time to the first event and to the final status update. Use --rate to start
+requests at a fixed rate instead of as fast as the workers allow.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		rate, _ := cmd.Flags().GetFloat64("rate")
+		duration, _ := cmd.Flags().GetDuration("duration")
+		requests, _ := cmd.Flags().GetInt("requests")
+		stream, _ := cmd.Flags().GetBool("stream")
+		contextID, _ := cmd.Flags().GetString("context-id")
+
+		if concurrency < 1 {
+			return fmt.Errorf("--concurrency must be at least 1")
+		}
+		if rate < 0 || requests < 0 || duration <= 0 {
+			return fmt.Errorf("--rate and --requests must not be negative and --duration must be positive")
+		}
+		if rate > 0 && benchInterval(rate) <= 0 {
+			return fmt.Errorf("--rate must be at most %d requests per second", int64(time.Second))
+		}
+
+		ensureA2AClient()
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		method := "message/send"
+		if stream {
+			method = "message/stream"
+		}
+		fmt.Fprintf(os.Stderr, "🏋️  Benchmarking %s on %s for %s with %d worker(s)...\n", method, viper.GetString("server-url"), duration, concurrency)
+
+		report := runBench(ctx, newBenchClient(), benchOptions{
+			Message:     args[0],
+			ContextID:   contextID,
+			Stream:      stream,
+			Concurrency: concurrency,
+			Rate:        rate,
+			Duration:    duration,
+			Requests:    requests,
+		})
+
+		return printFormattedTable(report, func(wide bool) tableView {
+			return benchTable(report)
+		})
+	},
+}
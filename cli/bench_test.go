@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	adk "github.com/inference-gateway/adk/types"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+func TestRunBench_MockServer(t *testing.T) {
+	a2a := newTestMockClient(t, "responses:\n  - steps:\n      - state: working\n      - state: completed\n        text: done\n")
+
+	report := runBench(context.Background(), a2a, benchOptions{Message: "hi", Concurrency: 4, Duration: 5 * time.Second, Requests: 20})
+	if report.Requests != 20 || report.Succeeded != 20 || report.Failed != 0 {
+		t.Fatalf("Expected 20 successful requests, got %+v", report)
+	}
+	if report.Method != "message/send" || report.Latency == nil || report.Latency.Count != 20 {
+		t.Errorf("Expected latency stats for every request, got %+v", report)
+	}
+	if report.TimeToFirstEvent != nil || report.TimeToFinalStatus != nil {
+		t.Error("Expected no stream timings for message/send")
+	}
+
+	report = runBench(context.Background(), a2a, benchOptions{Message: "hi", Stream: true, Concurrency: 2, Duration: 5 * time.Second, Requests: 10})
+	if report.Succeeded != 10 || report.Method != "message/stream" {
+		t.Fatalf("Expected 10 successful streams, got %+v", report)
+	}
+	if report.TimeToFirstEvent == nil || report.TimeToFinalStatus == nil || report.TimeToFinalStatus.Count != 10 {
+		t.Errorf("Expected stream timings for every request, got %+v", report)
+	}
+}
+
+func TestRunBench_ErrorBreakdown(t *testing.T) {
+	a2a := newTestMockClient(t, "responses:\n  - match: x\n    times: 3\n    error:\n      code: -32603\n      message: boom\n  - steps:\n      - state: failed\n")
+
+	report := runBench(context.Background(), a2a, benchOptions{Message: "x", Concurrency: 1, Duration: 5 * time.Second, Requests: 5})
+	if report.Failed != 5 || report.Errors["jsonrpc:-32603"] != 3 || report.Errors["task-failed"] != 2 {
+		t.Errorf("Unexpected error breakdown: %+v", report.Errors)
+	}
+	if report.Latency != nil {
+		t.Errorf("Expected no latency stats without successful requests, got %+v", report.Latency)
+	}
+}
+
+func TestBenchErrorClass(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		expected string
+	}{
+		"JSON-RPC error":  {errors.New("A2A error: Task not found (code: -32001)"), "jsonrpc:-32001"},
+		"HTTP status":     {errors.New("unexpected status code: 503, body: unavailable"), "http:503"},
+		"Error body":      {errors.New(`unexpected status code: 500, body: {"error":{"code":-32603,"message":"boom"}}`), "jsonrpc:-32603"},
+		"Deadline":        {fmt.Errorf("failed to send request: %w", context.DeadlineExceeded), benchErrorTimeout},
+		"Connection fail": {errors.New("dial tcp 127.0.0.1:1: connect: connection refused"), benchErrorTransport},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := benchErrorClass(tt.err); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestLatencyStatsOf(t *testing.T) {
+	var latencies []time.Duration
+	for i := 100; i >= 1; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	stats := latencyStatsOf(latencies)
+	if stats.Count != 100 || stats.Min != 1 || stats.Max != 100 || stats.Mean != 50.5 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+	if stats.P50 != 50 || stats.P90 != 90 || stats.P95 != 95 || stats.P99 != 99 {
+		t.Errorf("Unexpected percentiles: %+v", stats)
+	}
+	if latencyStatsOf(nil) != nil {
+		t.Error("Expected nil stats without latencies")
+	}
+}
+
+func TestNewBenchClient_NoRetries(t *testing.T) {
+	originalHTTPClient, originalLogger := a2aHTTPClient, logger
+	defer func() { a2aHTTPClient, logger = originalHTTPClient, originalLogger }()
+	logger = zap.NewNop()
+
+	attempts := 0
+	a2aHTTPClient = &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		return nil, errors.New("connection refused")
+	})}
+
+	start := time.Now()
+	text := "hi"
+	_, err := newBenchClient().SendTask(context.Background(), adk.MessageSendParams{Message: adk.Message{MessageID: "m1", Role: adk.RoleUser, Parts: []adk.Part{{Text: &text}}}})
+	if err == nil || attempts != 1 {
+		t.Errorf("Expected a single failed attempt, got %d (%v)", attempts, err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected the failure without retry delays, took %s", elapsed)
+	}
+}
+
+func TestBenchCmd_RateTooHigh(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().Int("concurrency", 1, "")
+	cmd.Flags().Float64("rate", 2e9, "")
+	cmd.Flags().Duration("duration", time.Second, "")
+	cmd.Flags().Int("requests", 0, "")
+	cmd.Flags().Bool("stream", false, "")
+	cmd.Flags().String("context-id", "", "")
+
+	if err := benchCmd.RunE(cmd, []string{"hi"}); err == nil || !strings.Contains(err.Error(), "--rate") {
+		t.Errorf("Expected a rate above one request per nanosecond to be rejected, got %v", err)
+	}
+}
@@ -1,12 +1,15 @@
 package cli
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"strings"
+	"text/template"
 	"time"
 
 	cobra "github.com/spf13/cobra"
@@ -23,19 +26,26 @@ var (
 	logger    *zap.Logger
 	a2aClient client.A2AClient
 
+	// a2aHTTPClient is the HTTP client of a2aClient, for requests the client does not cover
+	a2aHTTPClient *http.Client
+
 	appVersion  string
 	buildCommit string
 	buildDate   string
 )
 
+// maxResponseBodySize caps the size of a response body read into memory from the agent
+const maxResponseBodySize = 10 << 20
+
 var rootCmd = &cobra.Command{
 	Use:   "a2a",
 	Short: "A debugging tool for A2A (Agent-to-Agent) servers",
 	Long: `A2A Debugger is a command-line tool for debugging and monitoring A2A servers.
 It allows you to connect to A2A servers, list tasks, view conversation histories,
 and inspect task statuses.`,
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		initLogger()
+		return validateOutputFormat()
 	},
 }
 
@@ -52,9 +62,11 @@ Commit:     ` + commit + `
 Built:      ` + date + `
 `)
 
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+	rootCmd.SilenceErrors = true
+	err := rootCmd.Execute()
+	flushHARArchive()
+	if err != nil {
+		os.Exit(reportError(err))
 	}
 }
 
@@ -66,7 +78,18 @@ func init() {
 	rootCmd.PersistentFlags().Duration("timeout", 30*time.Second, "Request timeout")
 	rootCmd.PersistentFlags().Bool("debug", false, "Enable debug logging")
 	rootCmd.PersistentFlags().Bool("insecure", false, "Skip TLS verification")
-	rootCmd.PersistentFlags().StringP("output", "o", "yaml", "Output format (yaml|json)")
+	rootCmd.PersistentFlags().String("ca-cert", "", "PEM encoded CA bundle used to verify the server certificate")
+	rootCmd.PersistentFlags().String("client-cert", "", "PEM encoded client certificate for mutual TLS")
+	rootCmd.PersistentFlags().String("client-key", "", "PEM encoded client private key for mutual TLS")
+	rootCmd.PersistentFlags().String("tls-server-name", "", "Server name used to verify the server certificate (overrides the URL host)")
+	rootCmd.PersistentFlags().String("token", "", "Bearer token sent in the Authorization header of every request")
+	rootCmd.PersistentFlags().String("api-key", "", "API key sent with every request")
+	rootCmd.PersistentFlags().String("api-key-header", defaultAPIKeyHeader, "Header used to send the API key")
+	rootCmd.PersistentFlags().StringArray("header", nil, "Custom header sent with every request as key=value (repeatable)")
+	rootCmd.PersistentFlags().Bool("trace-http", false, "Print every HTTP request and response exchanged with the A2A server, including SSE frames, to stderr")
+	rootCmd.PersistentFlags().String("har", "", "Write the HTTP traffic exchanged with the A2A server to an HTTP Archive (HAR) file")
+	rootCmd.PersistentFlags().String("faults", "", "YAML rule file of faults injected into the traffic to the A2A server")
+	rootCmd.PersistentFlags().StringP("output", "o", "yaml", "Output format (yaml|json|ndjson|table|wide|jsonpath=...|go-template=...|go-template-file=...)")
 
 	err := viper.BindPFlag("server-url", rootCmd.PersistentFlags().Lookup("server-url"))
 	if err != nil {
@@ -88,6 +111,28 @@ func init() {
 		log.Fatalf("bind error: %v", err)
 	}
 
+	for _, key := range []string{"ca-cert", "client-cert", "client-key", "tls-server-name", "token", "api-key", "api-key-header", "header", "faults", "trace-http", "har"} {
+		err = viper.BindPFlag(key, rootCmd.PersistentFlags().Lookup(key))
+		if err != nil {
+			log.Fatalf("bind error: %v", err)
+		}
+	}
+
+	err = viper.BindEnv("token", "A2A_TOKEN")
+	if err != nil {
+		log.Fatalf("bind error: %v", err)
+	}
+
+	err = viper.BindEnv("api-key", "A2A_API_KEY")
+	if err != nil {
+		log.Fatalf("bind error: %v", err)
+	}
+
+	err = viper.BindEnv("header", "A2A_HEADERS")
+	if err != nil {
+		log.Fatalf("bind error: %v", err)
+	}
+
 	err = viper.BindPFlag("output", rootCmd.PersistentFlags().Lookup("output"))
 	if err != nil {
 		log.Fatalf("bind error: %v", err)
@@ -102,13 +147,44 @@ func init() {
 	tasksCmd.AddCommand(historyCmd)
 	tasksCmd.AddCommand(submitTaskCmd)
 	tasksCmd.AddCommand(submitStreamingTaskCmd)
+	tasksCmd.AddCommand(cancelTaskCmd)
+	tasksCmd.AddCommand(resubscribeTaskCmd)
+	tasksCmd.AddCommand(pushConfigCmd)
+
+	pushConfigCmd.AddCommand(pushConfigSetCmd)
+	pushConfigCmd.AddCommand(pushConfigGetCmd)
+	pushConfigCmd.AddCommand(pushConfigListCmd)
+	pushConfigCmd.AddCommand(pushConfigDeleteCmd)
+
+	webhookCmd.AddCommand(webhookListenCmd)
+
+	agentCardCmd.AddCommand(agentCardValidateCmd)
+
+	authCmd.AddCommand(authLoginCmd)
+	authCmd.AddCommand(authLogoutCmd)
+	authCmd.AddCommand(authStatusCmd)
+
+	mockCmd.AddCommand(mockServeCmd)
+
+	conformanceCmd.AddCommand(conformanceRunCmd)
+	conformanceCmd.AddCommand(conformanceListCmd)
 
 	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(tasksCmd)
 	rootCmd.AddCommand(connectCmd)
+	rootCmd.AddCommand(healthCmd)
 	rootCmd.AddCommand(agentCardCmd)
 	rootCmd.AddCommand(interactiveCmd)
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(webhookCmd)
+	rootCmd.AddCommand(authCmd)
+	rootCmd.AddCommand(replayCmd)
+	rootCmd.AddCommand(mockCmd)
+	rootCmd.AddCommand(conformanceCmd)
+	rootCmd.AddCommand(benchCmd)
+	rootCmd.AddCommand(runCmd)
+	rootCmd.AddCommand(proxyCmd)
+	rootCmd.AddCommand(rpcCmd)
 
 	listTasksCmd.Flags().String("state", "", "Filter by task state (submitted, working, completed, failed)")
 	listTasksCmd.Flags().String("context-id", "", "Filter by context ID")
@@ -122,8 +198,78 @@ func init() {
 	submitStreamingTaskCmd.Flags().String("context-id", "", "Context ID for the task (optional, will generate new context if not provided)")
 	submitStreamingTaskCmd.Flags().String("task-id", "", "Task ID to resume (optional)")
 	submitStreamingTaskCmd.Flags().Bool("raw", false, "Show raw streaming event data instead of formatted output")
+	submitStreamingTaskCmd.Flags().String("record", "", "Record the streamed events with their timing to a file for offline replay")
+	submitTaskCmd.Flags().String("snapshot-name", "", "Name of the golden file in --snapshot-dir (default: derived from the message)")
+	submitStreamingTaskCmd.Flags().String("snapshot-name", "", "Name of the golden file in --snapshot-dir (default: derived from the message)")
+	addSnapshotFlags(submitTaskCmd)
+	addSnapshotFlags(submitStreamingTaskCmd)
+	submitTaskCmd.Flags().StringArray("file", nil, "Attach a local file, inlined as base64 (repeatable)")
+	submitTaskCmd.Flags().StringArray("file-uri", nil, "Attach a file by URI (repeatable)")
+	submitTaskCmd.Flags().StringArray("data", nil, "Attach structured data as a JSON object or @file (repeatable)")
+	submitTaskCmd.Flags().Int64("max-file-size", defaultMaxFileSizeMB, "Maximum size in MiB of an attached file or data payload")
+	submitTaskCmd.Flags().Bool("skip-input-mode-check", false, "Send attachments even if the agent card does not list their media types as input modes")
+	submitStreamingTaskCmd.Flags().StringArray("file", nil, "Attach a local file, inlined as base64 (repeatable)")
+	submitStreamingTaskCmd.Flags().StringArray("file-uri", nil, "Attach a file by URI (repeatable)")
+	submitStreamingTaskCmd.Flags().StringArray("data", nil, "Attach structured data as a JSON object or @file (repeatable)")
+	submitStreamingTaskCmd.Flags().Int64("max-file-size", defaultMaxFileSizeMB, "Maximum size in MiB of an attached file or data payload")
+	submitStreamingTaskCmd.Flags().Bool("skip-input-mode-check", false, "Send attachments even if the agent card does not list their media types as input modes")
+	resubscribeTaskCmd.Flags().Bool("raw", false, "Show raw streaming event data instead of formatted output")
+	cancelTaskCmd.Flags().String("all-in-context", "", "Cancel every task in the given context ID instead of a single task")
+	cancelTaskCmd.Flags().String("state", "working", "Only cancel tasks in this state when using --all-in-context")
+	pushConfigSetCmd.Flags().String("url", "", "Webhook URL that receives the push notifications (required)")
+	pushConfigSetCmd.Flags().String("config-id", "", "Push notification config ID (optional, generated by the server if not provided)")
+	pushConfigSetCmd.Flags().String("notification-token", "", "Token the server sends with each notification so the webhook can validate it")
+	pushConfigSetCmd.Flags().StringSlice("auth-scheme", nil, "Authentication scheme the server uses when calling the webhook (e.g. bearer, basic)")
+	pushConfigSetCmd.Flags().String("auth-credentials", "", "Credentials for the authentication scheme")
+	pushConfigListCmd.Flags().Int("page-size", 0, "Maximum number of configs to return")
+	pushConfigListCmd.Flags().String("page-token", "", "Page token returned by a previous list call")
+	webhookListenCmd.Flags().String("addr", ":9000", "Address the webhook receiver listens on")
+	webhookListenCmd.Flags().String("path", "/", "URL path the webhook receiver accepts notifications on")
+	webhookListenCmd.Flags().String("notification-token", "", "Reject notifications that do not carry this token (bearer or X-A2A-Notification-Token header)")
+	webhookListenCmd.Flags().String("jwt-secret", "", "Reject notifications whose bearer JWT is not signed with this HS256 secret")
+	webhookListenCmd.Flags().Bool("raw", false, "Show raw notification payloads instead of formatted output")
+	webhookListenCmd.Flags().String("task-id", "", "Register the receiver as the push notification config of this task and remove it on exit")
+	webhookListenCmd.Flags().String("public-url", "", "URL the agent uses to reach the receiver (defaults to the listen address)")
+	agentCardCmd.Flags().Bool("extended", false, "Fetch the authenticated extended agent card instead of the public one")
+	agentCardCmd.Flags().Bool("diff", false, "Show the skills, capabilities and fields that differ between the public and extended cards")
+	healthCmd.Flags().Bool("wait", false, "Poll until the server reaches the --until status or --max-wait elapses")
+	healthCmd.Flags().String("until", "healthy", "Status the server must reach (healthy, degraded)")
+	healthCmd.Flags().Duration("max-wait", 60*time.Second, "Maximum time to wait in --wait mode")
+	healthCmd.Flags().Duration("interval", 500*time.Millisecond, "Initial delay between health checks in --wait mode, doubled after each attempt")
+	agentCardValidateCmd.Flags().String("file", "", "Validate a local agent card JSON or YAML file (- for stdin) instead of the live card")
+	authLoginCmd.Flags().String("scheme", "", "Security scheme from the agent card to use (defaults to the first OAuth2 or OpenID Connect scheme)")
+	authLoginCmd.Flags().String("flow", oauthFlowAuto, "OAuth2 flow to use (auto, client-credentials, device-code)")
+	authLoginCmd.Flags().String("client-id", "", "OAuth2 client ID (required)")
+	authLoginCmd.Flags().String("client-secret", "", "OAuth2 client secret for the client credentials flow (or set A2A_CLIENT_SECRET)")
+	authLoginCmd.Flags().StringSlice("scopes", nil, "Scopes to request (defaults to the scopes required by the agent card)")
+	authLoginCmd.Flags().String("token-url", "", "Override the token URL advertised by the agent card")
+	authLoginCmd.Flags().String("device-authorization-url", "", "Device authorization endpoint for the device code flow")
 	interactiveCmd.Flags().BoolP("background", "b", false, "Use background (long-running task) mode instead of streaming")
 	interactiveCmd.Flags().String("context-id", "", "Resume an existing context ID (optional, a new one is generated otherwise)")
+	interactiveCmd.Flags().String("record", "", "Record the streamed events of the session with their timing to a file for offline replay")
+	mockServeCmd.Flags().String("addr", ":8080", "Address the mock server listens on")
+	mockServeCmd.Flags().String("scenario", "", "YAML scenario file scripting the agent card and responses (default: echo every message)")
+	conformanceRunCmd.Flags().StringSlice("only", nil, "Only run checks whose ID starts with one of these prefixes (e.g. tasks.,message.stream)")
+	conformanceRunCmd.Flags().String("junit", "", "Also write the report as JUnit XML to this file (- for stdout instead of the regular report)")
+	conformanceRunCmd.Flags().Duration("max-duration", 2*time.Minute, "Maximum time for the whole run, including waiting for tasks to finish")
+	benchCmd.Flags().IntP("concurrency", "c", 10, "Number of concurrent workers sending requests")
+	benchCmd.Flags().Float64("rate", 0, "Target request rate per second across all workers (default: as fast as the workers allow)")
+	benchCmd.Flags().Duration("duration", 10*time.Second, "How long to send requests for")
+	benchCmd.Flags().IntP("requests", "n", 0, "Stop after this many requests (default: no limit)")
+	benchCmd.Flags().Bool("stream", false, "Use message/stream instead of message/send")
+	benchCmd.Flags().String("context-id", "", "Context ID shared by every request (optional)")
+	runCmd.Flags().Bool("stream", false, "Send every turn with message/stream (overrides the scenario)")
+	runCmd.Flags().Duration("turn-timeout", defaultTurnTimeout, "Maximum time for a single turn (overrides the scenario)")
+	runCmd.Flags().String("context-id", "", "Context ID for the conversation (overrides the scenario)")
+	addSnapshotFlags(runCmd)
+	proxyCmd.Flags().String("listen", ":8081", "Address the proxy listens on")
+	proxyCmd.Flags().String("upstream", "", "URL of the A2A server traffic is forwarded to (default: --server-url)")
+	proxyCmd.Flags().String("capture", "", "Write message/send and streaming traffic to a recording for replay")
+	proxyCmd.Flags().Bool("tui", false, "Show the traffic in a live terminal view")
+
+	rpcCmd.Flags().String("id", "", "ID of the JSON-RPC request; integers are sent as numbers (default: a generated UUID)")
+	replayCmd.Flags().String("speed", "", "Replay at a multiple of the recorded pace, e.g. 1x or 10x (default: no delay)")
+	replayCmd.Flags().Bool("raw", false, "Show raw streaming event data instead of formatted output")
 }
 
 func initConfig() {
@@ -162,12 +308,60 @@ func initA2AClient() {
 	serverURL := viper.GetString("server-url")
 	timeout := viper.GetDuration("timeout")
 
+	headers, err := requestHeaders()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to configure request headers: %v\n", err)
+		os.Exit(1)
+	}
+
+	if headers.Get("Authorization") == "" {
+		attachCachedToken(headers, serverURL, timeout)
+	}
+	logger = withSecretRedaction(logger, headerSecrets(headers))
+
 	config := client.DefaultConfig(serverURL)
 	config.Timeout = timeout
 	config.Logger = logger
 
+	var wrappers []func(http.RoundTripper) http.RoundTripper
+	if faultsPath := viper.GetString("faults"); faultsPath != "" {
+		rules, err := loadFaultRules(faultsPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to load fault rules: %v\n", err)
+			os.Exit(1)
+		}
+		wrappers = append(wrappers, func(base http.RoundTripper) http.RoundTripper {
+			return newFaultTransport(base, rules, logInjectedFault)
+		})
+		// Retries would roll every rule again, so a dropped request fails once
+		config.MaxRetries = 0
+	}
+
+	tracer, err := httpTracerFromConfig(headerSecrets(headers))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to configure HTTP tracing: %v\n", err)
+		os.Exit(1)
+	}
+	if tracer != nil {
+		wrappers = append(wrappers, tracer)
+	}
+	wrappers = append(wrappers, func(base http.RoundTripper) http.RoundTripper {
+		return &rpcErrorTransport{base: base, recorder: &lastRPCError}
+	})
+
+	httpClient, err := newHTTPClient(timeout, headers, wrappers...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to configure HTTP client: %v\n", err)
+		os.Exit(1)
+	}
+
 	a2aClient = client.NewClientWithConfig(config)
-	logger.Debug("A2A client initialized", zap.String("server_url", serverURL))
+	a2aClient.SetHTTPClient(httpClient)
+	a2aHTTPClient = httpClient
+	logger.Debug("A2A client initialized",
+		zap.String("server_url", serverURL),
+		zap.Bool("insecure", viper.GetBool("insecure")),
+		zap.Any("headers", redactHeaders(headers)))
 }
 
 // ensureA2AClient initializes the A2A client if it hasn't been initialized yet
@@ -177,68 +371,147 @@ func ensureA2AClient() {
 	}
 }
 
-// handleA2AError checks if the error is a MethodNotFoundError and returns a user-friendly message
-func handleA2AError(err error, method string) error {
-	if err == nil {
-		return nil
+// parseTaskState accepts either the short form of a task state (e.g. "working")
+// or the full protocol value (e.g. "TASK_STATE_WORKING")
+func parseTaskState(state string) adk.TaskState {
+	upper := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(state), "-", "_"))
+	if !strings.HasPrefix(upper, "TASK_STATE_") {
+		upper = "TASK_STATE_" + upper
 	}
+	return adk.TaskState(upper)
+}
 
-	errStr := err.Error()
+// OutputFormat represents supported output formats
+type OutputFormat string
 
-	if strings.Contains(errStr, "MethodNotFoundError") || strings.Contains(errStr, "-32601") {
-		displayMethod := method
-		if displayMethod == "" {
-			displayMethod = "method"
-		}
+const (
+	OutputFormatYAML           OutputFormat = "yaml"
+	OutputFormatJSON           OutputFormat = "json"
+	OutputFormatTable          OutputFormat = "table"
+	OutputFormatWide           OutputFormat = "wide"
+	OutputFormatNDJSON         OutputFormat = "ndjson"
+	OutputFormatJSONPath       OutputFormat = "jsonpath"
+	OutputFormatGoTemplate     OutputFormat = "go-template"
+	OutputFormatGoTemplateFile OutputFormat = "go-template-file"
+)
 
-		return fmt.Errorf("❌ Method '%s' not implemented by the agent", displayMethod)
-	}
+// parseOutputFormat splits an output value such as "jsonpath={.status.state}" into
+// its format and template argument
+func parseOutputFormat(value string) (OutputFormat, string, error) {
+	name, arg, hasArg := strings.Cut(value, "=")
+	format := OutputFormat(strings.ToLower(strings.TrimSpace(name)))
 
-	var jsonErr struct {
-		Error *adk.JSONRPCError `json:"error,omitempty"`
-	}
-	if jsonParseErr := json.Unmarshal([]byte(errStr), &jsonErr); jsonParseErr == nil && jsonErr.Error != nil && jsonErr.Error.Code == -32601 {
-		displayMethod := method
-		if displayMethod == "" {
-			displayMethod = "method"
+	switch format {
+	case OutputFormatJSONPath, OutputFormatGoTemplate, OutputFormatGoTemplateFile:
+		if !hasArg || arg == "" {
+			return "", "", fmt.Errorf("output format %q requires a template, e.g. -o %s=...", format, format)
 		}
-		return fmt.Errorf("❌ Method '%s' not implemented by the agent", displayMethod)
+		return format, arg, nil
+	case OutputFormatJSON, OutputFormatTable, OutputFormatWide, OutputFormatNDJSON:
+	case OutputFormatYAML, "":
+		format = OutputFormatYAML
+	default:
+		return "", "", fmt.Errorf("unknown output format %q (use yaml, json, ndjson, table, wide, jsonpath=, go-template= or go-template-file=)", value)
 	}
 
-	return err
+	if hasArg {
+		return "", "", fmt.Errorf("output format %q does not take an argument", format)
+	}
+	return format, "", nil
 }
 
-// OutputFormat represents supported output formats
-type OutputFormat string
+// getOutputFormat returns the configured output format
+func getOutputFormat() (OutputFormat, error) {
+	format, _, err := parseOutputFormat(viper.GetString("output"))
+	return format, err
+}
 
-const (
-	OutputFormatYAML OutputFormat = "yaml"
-	OutputFormatJSON OutputFormat = "json"
-)
+// validateOutputFormat checks the configured output format, compiling any template
+// so that mistakes are reported before a command talks to the server
+func validateOutputFormat() error {
+	format, arg, err := parseOutputFormat(viper.GetString("output"))
+	if err != nil {
+		return err
+	}
+	_, err = outputTemplate(format, arg)
+	return err
+}
 
-// getOutputFormat returns the configured output format
-func getOutputFormat() OutputFormat {
-	format := viper.GetString("output")
-	switch strings.ToLower(format) {
-	case "json":
-		return OutputFormatJSON
-	case "yaml":
-		return OutputFormatYAML
+// outputTemplate compiles the template of a jsonpath, go-template or go-template-file
+// output format. It returns nil for the other formats.
+func outputTemplate(format OutputFormat, arg string) (func(data any) ([]byte, error), error) {
+	switch format {
+	case OutputFormatJSONPath:
+		tmpl, err := parseJSONPath(arg)
+		if err != nil {
+			return nil, err
+		}
+		return tmpl.execute, nil
+	case OutputFormatGoTemplateFile:
+		content, err := os.ReadFile(arg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template file: %w", err)
+		}
+		arg = string(content)
+		fallthrough
+	case OutputFormatGoTemplate:
+		tmpl, err := template.New("output").Funcs(template.FuncMap{
+			"json": func(value any) (string, error) {
+				b, err := json.Marshal(value)
+				return string(b), err
+			},
+		}).Parse(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid go-template: %w", err)
+		}
+		return func(data any) ([]byte, error) {
+			var buf bytes.Buffer
+			if err := tmpl.Execute(&buf, nativeNumbers(data)); err != nil {
+				return nil, fmt.Errorf("failed to execute go-template: %w", err)
+			}
+			return buf.Bytes(), nil
+		}, nil
 	default:
-		return OutputFormatYAML // Default to YAML
+		return nil, nil
 	}
 }
 
 // formatOutput formats the given data according to the specified format
 func formatOutput(data any) ([]byte, error) {
-	format := getOutputFormat()
+	format, arg, err := parseOutputFormat(viper.GetString("output"))
+	if err != nil {
+		return nil, err
+	}
 	switch format {
 	case OutputFormatJSON:
 		return json.MarshalIndent(data, "", "  ")
 	case OutputFormatYAML:
 		return yaml.Marshal(data)
+	case OutputFormatNDJSON:
+		output, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+		return append(output, '\n'), nil
+	case OutputFormatJSONPath, OutputFormatGoTemplate, OutputFormatGoTemplateFile:
+		render, err := outputTemplate(format, arg)
+		if err != nil {
+			return nil, err
+		}
+		value, err := toJSONValue(data)
+		if err != nil {
+			return nil, err
+		}
+		output, err := render(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(output) > 0 && !bytes.HasSuffix(output, []byte("\n")) {
+			output = append(output, '\n')
+		}
+		return output, nil
 	default:
-		return yaml.Marshal(data)
+		return nil, fmt.Errorf("output format %q is not supported by this command", format)
 	}
 }
 
@@ -252,6 +525,20 @@ func printFormatted(data any) error {
 	return nil
 }
 
+// decodeResult converts a JSON-RPC result into the given target type
+func decodeResult(result any, target any) error {
+	resultBytes, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	if err := json.Unmarshal(resultBytes, target); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
+
 // Config namespace command
 var configCmd = &cobra.Command{
 	Use:   "config",
@@ -308,7 +595,9 @@ var configListCmd = &cobra.Command{
 	Long:  "List all configuration values from the A2A debugger config file.",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		settings := viper.AllSettings()
-		return printFormatted(settings)
+		return printFormattedTable(settings, func(wide bool) tableView {
+			return settingsTable(settings)
+		})
 	},
 }
 
@@ -416,7 +705,9 @@ var listTasksCmd = &cobra.Command{
 			"showing": len(tasks),
 		}
 
-		return printFormatted(output)
+		return printFormattedTable(output, func(wide bool) tableView {
+			return tasksTable(tasks, wide)
+		})
 	},
 }
 
@@ -499,18 +790,137 @@ var historyCmd = &cobra.Command{
 			"tasks":      taskList.Tasks,
 		}
 
-		return printFormatted(output)
+		return printFormattedTable(output, func(wide bool) tableView {
+			return historyTable(taskList.Tasks, wide)
+		})
+	},
+}
+
+var cancelTaskCmd = &cobra.Command{
+	Use:   "cancel [task-id]",
+	Short: "Cancel a running task",
+	Long: `Cancels a task on the A2A server and displays the resulting task.
+
+Use --all-in-context to cancel every task in a context that is in the state
+given by --state (working by default).`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := rejectTableOutput(); err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		ensureA2AClient()
+
+		contextID, _ := cmd.Flags().GetString("all-in-context")
+		state, _ := cmd.Flags().GetString("state")
+
+		if contextID == "" {
+			if len(args) != 1 {
+				return fmt.Errorf("either a task ID or --all-in-context is required")
+			}
+
+			task, err := cancelTask(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			return printFormatted(task)
+		}
+
+		if len(args) != 0 {
+			return fmt.Errorf("a task ID cannot be combined with --all-in-context")
+		}
+
+		params := adk.TaskListParams{
+			ContextID: &contextID,
+			Limit:     100,
+		}
+
+		if state != "" {
+			taskState := parseTaskState(state)
+			params.State = &taskState
+		}
+
+		logger.Debug("Listing tasks to cancel", zap.Any("params", params))
+
+		resp, err := a2aClient.ListTasks(ctx, params)
+		if err != nil {
+			return handleA2AError(err, "tasks/list")
+		}
+
+		resultBytes, err := json.Marshal(resp.Result)
+		if err != nil {
+			return fmt.Errorf("failed to marshal response: %w", err)
+		}
+
+		var taskList adk.TaskList
+		if err := json.Unmarshal(resultBytes, &taskList); err != nil {
+			return fmt.Errorf("failed to unmarshal task list: %w", err)
+		}
+
+		cancelled := []adk.Task{}
+		failed := []map[string]any{}
+		for _, task := range taskList.Tasks {
+			// Servers that ignore the state filter still return every task in the context.
+			if params.State != nil && task.Status.State != *params.State {
+				continue
+			}
+
+			cancelledTask, err := cancelTask(ctx, task.ID)
+			if err != nil {
+				failed = append(failed, map[string]any{
+					"task_id": task.ID,
+					"error":   err.Error(),
+				})
+				continue
+			}
+			cancelled = append(cancelled, cancelledTask)
+		}
+
+		output := map[string]any{
+			"context_id": contextID,
+			"cancelled":  cancelled,
+			"failed":     failed,
+		}
+
+		if err := printFormatted(output); err != nil {
+			return err
+		}
+
+		if len(failed) > 0 {
+			return fmt.Errorf("failed to cancel %d of %d tasks", len(failed), len(failed)+len(cancelled))
+		}
+		return nil
 	},
 }
 
+// cancelTask cancels a single task and returns its updated state
+func cancelTask(ctx context.Context, taskID string) (adk.Task, error) {
+	logger.Debug("Cancelling task", zap.String("task_id", taskID))
+
+	resp, err := a2aClient.CancelTask(ctx, adk.TaskIdParams{ID: taskID})
+	if err != nil {
+		return adk.Task{}, handleA2AError(err, "tasks/cancel")
+	}
+
+	return taskFromResult(resp.Result)
+}
+
 var agentCardCmd = &cobra.Command{
 	Use:   "agent-card",
 	Short: "Get agent card information",
-	Long:  "Retrieves the agent card information from the A2A server.",
+	Long: `Retrieves the agent card information from the A2A server.
+
+Use --extended to fetch the authenticated extended card when the public card
+advertises one, or --diff to show how the extended card differs from the public one.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
 		ensureA2AClient()
 
+		extended, _ := cmd.Flags().GetBool("extended")
+		diff, _ := cmd.Flags().GetBool("diff")
+
 		logger.Debug("Getting agent card")
 
 		agentCard, err := a2aClient.GetAgentCard(ctx)
@@ -518,7 +928,31 @@ var agentCardCmd = &cobra.Command{
 			return handleA2AError(err, "agent-card")
 		}
 
-		return printFormatted(agentCard)
+		if !extended && !diff {
+			return printFormattedTable(agentCard, func(wide bool) tableView {
+				return skillsTable(agentCard, wide)
+			})
+		}
+
+		logger.Debug("Getting authenticated extended agent card")
+
+		extendedCard, err := fetchExtendedAgentCard(ctx, agentCard)
+		if err != nil {
+			return err
+		}
+
+		if !diff {
+			return printFormattedTable(extendedCard, func(wide bool) tableView {
+				return skillsTable(extendedCard, wide)
+			})
+		}
+
+		cardDiff, err := diffAgentCards(agentCard, extendedCard)
+		if err != nil {
+			return err
+		}
+
+		return printFormatted(cardDiff)
 	},
 }
 
@@ -534,12 +968,25 @@ Press Ctrl+T to switch modes during a session and Ctrl+C to quit.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		background, _ := cmd.Flags().GetBool("background")
 		contextID, _ := cmd.Flags().GetString("context-id")
+		recordPath, _ := cmd.Flags().GetString("record")
 
 		mode := modeStreaming
 		if background {
 			mode = modeBackground
 		}
 
+		if recordPath != "" {
+			recorder, err := newStreamRecorder(recordPath, "interactive", viper.GetString("server-url"))
+			if err != nil {
+				return err
+			}
+			chatRecorder = recorder
+			defer func() {
+				_ = recorder.Close()
+				chatRecorder = nil
+			}()
+		}
+
 		return runInteractiveChat(mode, contextID)
 	},
 }
@@ -559,12 +1006,40 @@ var versionCmd = &cobra.Command{
 	},
 }
 
+// buildMessageSendParams builds the parameters of a message/send or message/stream
+// request for a user text message. Empty context and task IDs are left unset.
+func buildMessageSendParams(messageID, message, contextID, taskID string) adk.MessageSendParams {
+	params := adk.MessageSendParams{
+		Message: adk.Message{
+			MessageID: messageID,
+			Role:      adk.RoleUser,
+			Parts: []adk.Part{
+				{Text: &message},
+			},
+		},
+	}
+
+	if contextID != "" {
+		params.Message.ContextID = &contextID
+	}
+
+	if taskID != "" {
+		params.Message.TaskID = &taskID
+	}
+
+	return params
+}
+
 var submitTaskCmd = &cobra.Command{
 	Use:   "submit [message]",
 	Short: "Submit a new task to the A2A server",
 	Long:  "Submits a new task to the A2A server with the specified message.",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := rejectTableOutput(); err != nil {
+			return err
+		}
+
 		ctx := context.Background()
 		ensureA2AClient()
 
@@ -572,26 +1047,26 @@ var submitTaskCmd = &cobra.Command{
 		contextID, _ := cmd.Flags().GetString("context-id")
 		taskID, _ := cmd.Flags().GetString("task-id")
 
-		messageID := fmt.Sprintf("msg-%d", time.Now().Unix())
-
-		params := adk.MessageSendParams{
-			Message: adk.Message{
-				MessageID: messageID,
-				Role:      adk.RoleUser,
-				Parts: []adk.Part{
-					{Text: &message},
-				},
-			},
+		snapshots, err := snapshotOptionsFromFlags(cmd)
+		if err != nil {
+			return err
 		}
 
-		if contextID != "" {
-			params.Message.ContextID = &contextID
+		parts, err := messagePartsFromFlags(cmd)
+		if err != nil {
+			return err
 		}
-
-		if taskID != "" {
-			params.Message.TaskID = &taskID
+		if skipCheck, _ := cmd.Flags().GetBool("skip-input-mode-check"); len(parts) > 0 && !skipCheck {
+			if err := checkInputModes(ctx, parts); err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
 		}
 
+		messageID := fmt.Sprintf("msg-%d", time.Now().Unix())
+		params := buildMessageSendParams(messageID, message, contextID, taskID)
+		params.Message.Parts = append(params.Message.Parts, parts...)
+
 		logger.Debug("submitting new task", zap.String("message", message), zap.String("context_id", contextID), zap.String("task_id", taskID))
 
 		resp, err := a2aClient.SendTask(ctx, params)
@@ -615,7 +1090,11 @@ var submitTaskCmd = &cobra.Command{
 			"task":       task,
 		}
 
-		return printFormatted(output)
+		if err := printFormatted(output); err != nil {
+			return err
+		}
+
+		return checkTaskSnapshot(cmd, snapshots, message, task)
 	},
 }
 
@@ -632,233 +1111,136 @@ var submitStreamingTaskCmd = &cobra.Command{
 		contextID, _ := cmd.Flags().GetString("context-id")
 		taskID, _ := cmd.Flags().GetString("task-id")
 		showRaw, _ := cmd.Flags().GetBool("raw")
+		recordPath, _ := cmd.Flags().GetString("record")
+
+		snapshots, err := snapshotOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		parts, err := messagePartsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		if skipCheck, _ := cmd.Flags().GetBool("skip-input-mode-check"); len(parts) > 0 && !skipCheck {
+			if err := checkInputModes(ctx, parts); err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+		}
 
 		messageID := fmt.Sprintf("msg-%d", time.Now().Unix())
 		startTime := time.Now()
+		params := buildMessageSendParams(messageID, message, contextID, taskID)
+		params.Message.Parts = append(params.Message.Parts, parts...)
 
-		params := adk.MessageSendParams{
-			Message: adk.Message{
-				MessageID: messageID,
-				Role:      adk.RoleUser,
-				Parts: []adk.Part{
-					{Text: &message},
-				},
-			},
-		}
+		logger.Debug("submitting new streaming task", zap.String("message", message), zap.String("context_id", contextID), zap.String("task_id", taskID))
 
-		if contextID != "" {
-			params.Message.ContextID = &contextID
+		var recorder *streamRecorder
+		if recordPath != "" {
+			var err error
+			recorder, err = newStreamRecorder(recordPath, "submit-streaming", viper.GetString("server-url"))
+			if err != nil {
+				return err
+			}
+			defer func() { _ = recorder.Close() }()
 		}
 
-		if taskID != "" {
-			params.Message.TaskID = &taskID
+		stream := 0
+		if recorder != nil {
+			stream = recorder.startStream("message/stream", params)
 		}
 
-		logger.Debug("submitting new streaming task", zap.String("message", message), zap.String("context_id", contextID), zap.String("task_id", taskID))
-
 		respChan, err := a2aClient.SendTaskStreaming(ctx, params)
 		if err != nil {
 			return handleA2AError(err, "message/stream")
 		}
 
-		fmt.Printf("✅ Streaming task submitted successfully!\n\n")
-		if contextID != "" {
-			fmt.Printf("Context ID: %s\n", contextID)
+		if recorder != nil {
+			respChan = recorder.tee(stream, respChan)
 		}
-		fmt.Printf("Message ID: %s\n", messageID)
-		fmt.Printf("\n🔄 Streaming responses:\n\n")
 
-		var streamingSummary struct {
-			TaskID          string
-			ContextID       string
-			FinalStatus     string
-			StatusUpdates   int
-			ArtifactUpdates int
-			TotalEvents     int
-			FinalMessage    *adk.Message
+		output := streamOutputFor(showRaw)
+		if output != streamOutputNDJSON {
+			fmt.Printf("✅ Streaming task submitted successfully!\n\n")
+			if contextID != "" {
+				fmt.Printf("Context ID: %s\n", contextID)
+			}
+			fmt.Printf("Message ID: %s\n", messageID)
+			fmt.Printf("\n🔄 Streaming responses:\n\n")
 		}
 
-		for resp := range respChan {
-			streamingSummary.TotalEvents++
+		summary := consumeStream(respChan, output)
+		printStreamingResult(output, summary, time.Since(startTime))
 
-			eventJSON, err := json.Marshal(resp.Result)
-			if err != nil {
-				logger.Error("Failed to marshal event", zap.Error(err))
-				continue
-			}
+		if snapshots.Dir == "" {
+			return nil
+		}
+		if summary.TaskID == "" {
+			return fmt.Errorf("stream ended without identifying a task to snapshot")
+		}
+		resp, err := a2aClient.GetTask(ctx, adk.TaskQueryParams{ID: summary.TaskID})
+		if err != nil {
+			return handleA2AError(err, "tasks/get")
+		}
+		task, err := taskFromResult(resp.Result)
+		if err != nil {
+			return err
+		}
+		return checkTaskSnapshot(cmd, snapshots, message, task)
+	},
+}
 
-			var genericEvent map[string]any
-			if err := json.Unmarshal(eventJSON, &genericEvent); err != nil {
-				logger.Error("Failed to unmarshal generic event", zap.Error(err))
-				continue
-			}
+// checkTaskSnapshot compares the task created by a submitted message with its
+// golden file when --snapshot-dir is set. The file is named after --snapshot-name,
+// or after the message when no name is given.
+func checkTaskSnapshot(cmd *cobra.Command, snapshots snapshotOptions, message string, task adk.Task) error {
+	if snapshots.Dir == "" {
+		return nil
+	}
 
-			_, hasArtifact := genericEvent["artifact"]
-			_, hasFinal := genericEvent["final"]
-			_, hasID := genericEvent["id"]
-
-			eventKind := ""
-			switch {
-			case hasArtifact:
-				eventKind = "artifact-update"
-			case hasFinal:
-				eventKind = "status-update"
-			case hasID:
-				eventKind = "task"
-			}
+	name, _ := cmd.Flags().GetString("snapshot-name")
+	if name == "" {
+		name = snapshotName(message)
+	}
 
-			switch eventKind {
-			case "status-update":
-				streamingSummary.StatusUpdates++
-				var statusEvent adk.TaskStatusUpdateEvent
-				if err := json.Unmarshal(eventJSON, &statusEvent); err == nil {
-					if streamingSummary.TaskID == "" {
-						streamingSummary.TaskID = statusEvent.TaskID
-					}
-					if streamingSummary.ContextID == "" {
-						streamingSummary.ContextID = statusEvent.ContextID
-					}
-					streamingSummary.FinalStatus = string(statusEvent.Status.State)
-					if statusEvent.Status.Message != nil {
-						streamingSummary.FinalMessage = statusEvent.Status.Message
-					}
-				}
-			case "artifact-update":
-				streamingSummary.ArtifactUpdates++
-				var artifactEvent adk.TaskArtifactUpdateEvent
-				if err := json.Unmarshal(eventJSON, &artifactEvent); err == nil {
-					if streamingSummary.TaskID == "" {
-						streamingSummary.TaskID = artifactEvent.TaskID
-					}
-					if streamingSummary.ContextID == "" {
-						streamingSummary.ContextID = artifactEvent.ContextID
-					}
-				}
-			case "task":
-				var task adk.Task
-				if err := json.Unmarshal(eventJSON, &task); err == nil {
-					if streamingSummary.TaskID == "" {
-						streamingSummary.TaskID = task.ID
-					}
-					if streamingSummary.ContextID == "" {
-						streamingSummary.ContextID = task.ContextID
-					}
-					streamingSummary.FinalStatus = string(task.Status.State)
-					if task.Status.Message != nil {
-						streamingSummary.FinalMessage = task.Status.Message
-					}
-				}
-			}
+	outcome, diffs, err := snapshots.check(name, task)
+	if err != nil {
+		return err
+	}
+	return printSnapshotResult(cmd, name, outcome, diffs)
+}
 
-			if showRaw {
-				eventJSONFormatted, err := json.MarshalIndent(resp.Result, "", "  ")
-				if err != nil {
-					logger.Error("Failed to marshal event", zap.Error(err))
-					continue
-				}
-				fmt.Printf("📡 Raw Event:\n%s\n\n", eventJSONFormatted)
-			} else {
-				switch eventKind {
-				case "status-update":
-					var statusEvent adk.TaskStatusUpdateEvent
-					if err := json.Unmarshal(eventJSON, &statusEvent); err != nil {
-						logger.Error("Failed to unmarshal status event", zap.Error(err))
-						continue
-					}
-
-					fmt.Printf("📊 Status Update: %s", statusEvent.Status.State)
-					if statusEvent.Status.Message != nil {
-						fmt.Printf(" (Message: %s)", statusEvent.Status.Message.MessageID)
-					}
-					if statusEvent.Final {
-						fmt.Printf(" [FINAL]")
-					}
-					fmt.Printf("\n")
-
-					if statusEvent.Status.Message != nil && len(statusEvent.Status.Message.Parts) > 0 {
-						fmt.Printf("\n💬 Agent Response:\n")
-						for _, part := range statusEvent.Status.Message.Parts {
-							if part.Text != nil {
-								fmt.Printf("%s\n", *part.Text)
-							}
-						}
-						fmt.Printf("\n")
-					}
-
-				case "artifact-update":
-					var artifactEvent adk.TaskArtifactUpdateEvent
-					if err := json.Unmarshal(eventJSON, &artifactEvent); err != nil {
-						logger.Error("Failed to unmarshal artifact event", zap.Error(err))
-						continue
-					}
-
-					fmt.Printf("📄 Artifact Update:\n")
-					fmt.Printf("  Artifact ID: %s\n", artifactEvent.Artifact.ArtifactID)
-					if artifactEvent.Artifact.Name != nil {
-						fmt.Printf("  Name: %s\n", *artifactEvent.Artifact.Name)
-					}
-					if artifactEvent.Artifact.Description != nil {
-						fmt.Printf("  Description: %s\n", *artifactEvent.Artifact.Description)
-					}
-					if len(artifactEvent.Artifact.Parts) > 0 {
-						fmt.Printf("  Parts:\n")
-						for i, part := range artifactEvent.Artifact.Parts {
-							switch {
-							case part.Text != nil:
-								fmt.Printf("    Part %d: [text] %s\n", i+1, *part.Text)
-							case part.File != nil:
-								fmt.Printf("    Part %d: [file] %s\n", i+1, part.File.Name)
-							case part.Data != nil:
-								fmt.Printf("    Part %d: [data]\n", i+1)
-							}
-						}
-					}
-					if artifactEvent.LastChunk != nil && *artifactEvent.LastChunk {
-						fmt.Printf("  [LAST CHUNK]\n")
-					}
-
-				case "task":
-					var task adk.Task
-					if err := json.Unmarshal(eventJSON, &task); err != nil {
-						logger.Error("Failed to unmarshal task snapshot", zap.Error(err))
-						continue
-					}
-
-					fmt.Printf("📦 Task Snapshot: %s [%s]\n", task.ID, task.Status.State)
-					if task.Status.Message != nil && len(task.Status.Message.Parts) > 0 {
-						fmt.Printf("\n💬 Agent Response:\n")
-						for _, part := range task.Status.Message.Parts {
-							if part.Text != nil {
-								fmt.Printf("%s\n", *part.Text)
-							}
-						}
-						fmt.Printf("\n")
-					}
-
-				default:
-					fmt.Printf("🔔 Unknown Event\n")
-				}
-				fmt.Printf("\n")
-			}
-		}
+var resubscribeTaskCmd = &cobra.Command{
+	Use:   "resubscribe [task-id]",
+	Short: "Reattach to the event stream of a running task",
+	Long:  "Resubscribes to the streaming events of a task that is still running on the A2A server, for example after an interrupted submit-streaming session.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		ensureA2AClient()
+
+		taskID := args[0]
+		showRaw, _ := cmd.Flags().GetBool("raw")
 
-		duration := time.Since(startTime)
+		startTime := time.Now()
+
+		logger.Debug("resubscribing to task", zap.String("task_id", taskID))
 
-		fmt.Printf("✅ Streaming completed!\n\n")
-		fmt.Printf("📋 Streaming Summary:\n")
-		fmt.Printf("  Task ID: %s\n", streamingSummary.TaskID)
-		fmt.Printf("  Context ID: %s\n", streamingSummary.ContextID)
-		fmt.Printf("  Final Status: %s\n", streamingSummary.FinalStatus)
-		fmt.Printf("  Duration: %s\n", duration.Round(time.Millisecond))
-		fmt.Printf("  Total Events: %d\n", streamingSummary.TotalEvents)
-		fmt.Printf("    Status Updates: %d\n", streamingSummary.StatusUpdates)
-		fmt.Printf("    Artifact Updates: %d\n", streamingSummary.ArtifactUpdates)
+		respChan, err := a2aClient.ResubscribeTask(ctx, adk.TaskResubscriptionParams{Name: taskID})
+		if err != nil {
+			return handleA2AError(err, "tasks/resubscribe")
+		}
 
-		if streamingSummary.FinalMessage != nil {
-			fmt.Printf("  Final Message Parts: %d\n", len(streamingSummary.FinalMessage.Parts))
+		output := streamOutputFor(showRaw)
+		if output != streamOutputNDJSON {
+			fmt.Printf("✅ Resubscribed to task successfully!\n\n")
+			fmt.Printf("Task ID: %s\n", taskID)
+			fmt.Printf("\n🔄 Streaming responses:\n\n")
 		}
 
-		fmt.Printf("\n")
+		summary := consumeStream(respChan, output)
+		printStreamingResult(output, summary, time.Since(startTime))
 		return nil
 	},
 }
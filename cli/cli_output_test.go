@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/spf13/viper"
@@ -16,6 +17,7 @@ func TestGetOutputFormat(t *testing.T) {
 		name           string
 		configValue    string
 		expectedFormat OutputFormat
+		expectError    bool
 	}{
 		{
 			name:           "Default YAML format",
@@ -38,9 +40,34 @@ func TestGetOutputFormat(t *testing.T) {
 			expectedFormat: OutputFormatJSON,
 		},
 		{
-			name:           "Invalid format defaults to YAML",
-			configValue:    "invalid",
-			expectedFormat: OutputFormatYAML,
+			name:           "Table format",
+			configValue:    "table",
+			expectedFormat: OutputFormatTable,
+		},
+		{
+			name:           "Wide format",
+			configValue:    "wide",
+			expectedFormat: OutputFormatWide,
+		},
+		{
+			name:           "JSONPath format",
+			configValue:    "jsonpath={.status.state}",
+			expectedFormat: OutputFormatJSONPath,
+		},
+		{
+			name:           "Go template format",
+			configValue:    "go-template={{.id}}",
+			expectedFormat: OutputFormatGoTemplate,
+		},
+		{
+			name:        "Invalid format is an error",
+			configValue: "invalid",
+			expectError: true,
+		},
+		{
+			name:        "JSONPath without template is an error",
+			configValue: "jsonpath=",
+			expectError: true,
 		},
 	}
 
@@ -53,7 +80,16 @@ func TestGetOutputFormat(t *testing.T) {
 				viper.Set("output", "yaml") // Set default
 			}
 
-			format := getOutputFormat()
+			format, err := getOutputFormat()
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected an error for format %q", tt.configValue)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
 			if format != tt.expectedFormat {
 				t.Errorf("Expected format %v, got %v", tt.expectedFormat, format)
 			}
@@ -104,6 +140,50 @@ func TestFormatOutput(t *testing.T) {
 				return nil
 			},
 		},
+		{
+			name:         "JSONPath output",
+			outputFormat: "jsonpath={.name}",
+			data:         testData,
+			validate: func(output []byte) error {
+				if string(output) != "test\n" {
+					return fmt.Errorf("expected 'test', got %q", output)
+				}
+				return nil
+			},
+		},
+		{
+			name:         "Go template output",
+			outputFormat: "go-template={{.name}} {{.version}}",
+			data:         testData,
+			validate: func(output []byte) error {
+				if string(output) != "test 1.0.0\n" {
+					return fmt.Errorf("expected 'test 1.0.0', got %q", output)
+				}
+				return nil
+			},
+		},
+		{
+			name:         "Go template number comparison",
+			outputFormat: "go-template={{if gt .count 100}}many{{else}}few{{end}} {{.ratio}}",
+			data:         map[string]any{"count": 250, "ratio": 0.5},
+			validate: func(output []byte) error {
+				if string(output) != "many 0.5\n" {
+					return fmt.Errorf("expected 'many 0.5', got %q", output)
+				}
+				return nil
+			},
+		},
+		{
+			name:         "Go template file output",
+			outputFormat: "go-template-file=" + writeTemplateFile(t, "{{json .active}}"),
+			data:         testData,
+			validate: func(output []byte) error {
+				if string(output) != "true\n" {
+					return fmt.Errorf("expected 'true', got %q", output)
+				}
+				return nil
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -189,3 +269,13 @@ func TestPrintFormatted(t *testing.T) {
 		})
 	}
 }
+
+// writeTemplateFile writes a go-template to a temporary file and returns its path
+func writeTemplateFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "output.tmpl")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+	return path
+}
@@ -3,6 +3,7 @@ package cli
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
@@ -13,6 +14,7 @@ import (
 	client "github.com/inference-gateway/adk/client"
 	adk "github.com/inference-gateway/adk/types"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"go.uber.org/zap"
 )
 
@@ -22,6 +24,14 @@ type mockA2AClient struct {
 	sendTaskFunc          func(ctx context.Context, params adk.MessageSendParams) (*adk.JSONRPCSuccessResponse, error)
 	getTaskFunc           func(ctx context.Context, params adk.TaskQueryParams) (*adk.JSONRPCSuccessResponse, error)
 	getAgentCardFunc      func(ctx context.Context) (*adk.AgentCard, error)
+	listTasksFunc         func(ctx context.Context, params adk.TaskListParams) (*adk.JSONRPCSuccessResponse, error)
+	cancelTaskFunc        func(ctx context.Context, params adk.TaskIdParams) (*adk.JSONRPCSuccessResponse, error)
+	resubscribeTaskFunc   func(ctx context.Context, params adk.TaskResubscriptionParams) (<-chan adk.JSONRPCSuccessResponse, error)
+	setPushConfigFunc     func(ctx context.Context, params adk.TaskPushNotificationConfig) (*adk.JSONRPCSuccessResponse, error)
+	listPushConfigFunc    func(ctx context.Context, params adk.ListTaskPushNotificationConfigParams) (*adk.JSONRPCSuccessResponse, error)
+	deletePushConfigFunc  func(ctx context.Context, params adk.DeleteTaskPushNotificationConfigParams) (*adk.JSONRPCSuccessResponse, error)
+	getExtendedCardFunc   func(ctx context.Context, params adk.GetAuthenticatedExtendedCardParams) (*adk.JSONRPCSuccessResponse, error)
+	getHealthFunc         func(ctx context.Context) (*client.HealthResponse, error)
 }
 
 func (m *mockA2AClient) GetAgentCard(ctx context.Context) (*adk.AgentCard, error) {
@@ -32,14 +42,23 @@ func (m *mockA2AClient) GetAgentCard(ctx context.Context) (*adk.AgentCard, error
 }
 
 func (m *mockA2AClient) GetAuthenticatedExtendedCard(ctx context.Context, params adk.GetAuthenticatedExtendedCardParams) (*adk.JSONRPCSuccessResponse, error) {
+	if m.getExtendedCardFunc != nil {
+		return m.getExtendedCardFunc(ctx, params)
+	}
 	return nil, fmt.Errorf("not implemented")
 }
 
 func (m *mockA2AClient) GetHealth(ctx context.Context) (*client.HealthResponse, error) {
+	if m.getHealthFunc != nil {
+		return m.getHealthFunc(ctx)
+	}
 	return nil, fmt.Errorf("not implemented")
 }
 
 func (m *mockA2AClient) ListTasks(ctx context.Context, params adk.TaskListParams) (*adk.JSONRPCSuccessResponse, error) {
+	if m.listTasksFunc != nil {
+		return m.listTasksFunc(ctx, params)
+	}
 	return nil, fmt.Errorf("not implemented")
 }
 
@@ -65,14 +84,23 @@ func (m *mockA2AClient) SendTaskStreaming(ctx context.Context, params adk.Messag
 }
 
 func (m *mockA2AClient) CancelTask(ctx context.Context, params adk.TaskIdParams) (*adk.JSONRPCSuccessResponse, error) {
+	if m.cancelTaskFunc != nil {
+		return m.cancelTaskFunc(ctx, params)
+	}
 	return nil, fmt.Errorf("not implemented")
 }
 
 func (m *mockA2AClient) ResubscribeTask(ctx context.Context, params adk.TaskResubscriptionParams) (<-chan adk.JSONRPCSuccessResponse, error) {
+	if m.resubscribeTaskFunc != nil {
+		return m.resubscribeTaskFunc(ctx, params)
+	}
 	return nil, fmt.Errorf("not implemented")
 }
 
 func (m *mockA2AClient) SetTaskPushNotificationConfig(ctx context.Context, params adk.TaskPushNotificationConfig) (*adk.JSONRPCSuccessResponse, error) {
+	if m.setPushConfigFunc != nil {
+		return m.setPushConfigFunc(ctx, params)
+	}
 	return nil, fmt.Errorf("not implemented")
 }
 
@@ -81,10 +109,16 @@ func (m *mockA2AClient) GetTaskPushNotificationConfig(ctx context.Context, param
 }
 
 func (m *mockA2AClient) ListTaskPushNotificationConfig(ctx context.Context, params adk.ListTaskPushNotificationConfigParams) (*adk.JSONRPCSuccessResponse, error) {
+	if m.listPushConfigFunc != nil {
+		return m.listPushConfigFunc(ctx, params)
+	}
 	return nil, fmt.Errorf("not implemented")
 }
 
 func (m *mockA2AClient) DeleteTaskPushNotificationConfig(ctx context.Context, params adk.DeleteTaskPushNotificationConfigParams) (*adk.JSONRPCSuccessResponse, error) {
+	if m.deletePushConfigFunc != nil {
+		return m.deletePushConfigFunc(ctx, params)
+	}
 	return nil, fmt.Errorf("not implemented")
 }
 
@@ -285,6 +319,102 @@ func TestSubmitStreamingTaskCmd_RawMode(t *testing.T) {
 	}
 }
 
+func TestSubmitStreamingTaskCmd_NDJSON(t *testing.T) {
+	originalClient := a2aClient
+	originalLogger := logger
+	logger = zap.NewNop()
+
+	mockClient := &mockA2AClient{
+		sendTaskStreamingFunc: func(ctx context.Context, params adk.MessageSendParams) (<-chan adk.JSONRPCSuccessResponse, error) {
+			ch := make(chan adk.JSONRPCSuccessResponse, 3)
+			ch <- adk.JSONRPCSuccessResponse{
+				Result: map[string]any{
+					"taskId":    "test-task-456",
+					"contextId": "test-context-789",
+					"final":     false,
+					"status":    map[string]any{"state": string(adk.TaskStateWorking)},
+				},
+			}
+			ch <- adk.JSONRPCSuccessResponse{
+				Result: map[string]any{
+					"taskId":    "test-task-456",
+					"contextId": "test-context-789",
+					"artifact":  map[string]any{"artifactId": "artifact-1", "parts": []any{map[string]any{"text": "hello"}}},
+				},
+			}
+			ch <- adk.JSONRPCSuccessResponse{
+				Result: map[string]any{
+					"taskId":    "test-task-456",
+					"contextId": "test-context-789",
+					"final":     true,
+					"status":    map[string]any{"state": string(adk.TaskStateCompleted)},
+				},
+			}
+			close(ch)
+			return ch, nil
+		},
+	}
+	a2aClient = mockClient
+	viper.Set("output", "ndjson")
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("context-id", "", "Context ID for the task")
+	cmd.Flags().String("task-id", "", "Task ID to resume")
+	cmd.Flags().Bool("raw", false, "Show raw streaming event data")
+
+	err := submitStreamingTaskCmd.RunE(cmd, []string{"test message"})
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r)
+
+	a2aClient = originalClient
+	logger = originalLogger
+	viper.Set("output", "yaml")
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Expected 3 event lines and a summary line, got %d:\n%s", len(lines), buf.String())
+	}
+
+	expectedKinds := []string{"status-update", "artifact-update", "status-update"}
+	for i, kind := range expectedKinds {
+		var event map[string]any
+		if err := json.Unmarshal([]byte(lines[i]), &event); err != nil {
+			t.Fatalf("Line %d is not valid JSON: %v\n%s", i+1, err, lines[i])
+		}
+		if event["type"] != "event" || event["kind"] != kind || event["seq"] != float64(i+1) {
+			t.Errorf("Unexpected event line %d: %v", i+1, event)
+		}
+		if _, err := time.Parse(time.RFC3339Nano, fmt.Sprint(event["received_at"])); err != nil {
+			t.Errorf("Expected RFC 3339 received_at on line %d, got %v", i+1, event["received_at"])
+		}
+		if _, ok := event["event"].(map[string]any); !ok {
+			t.Errorf("Expected the event payload on line %d, got %v", i+1, event["event"])
+		}
+	}
+
+	var summary map[string]any
+	if err := json.Unmarshal([]byte(lines[3]), &summary); err != nil {
+		t.Fatalf("Summary line is not valid JSON: %v", err)
+	}
+	if summary["type"] != "summary" || summary["task_id"] != "test-task-456" || summary["final_status"] != string(adk.TaskStateCompleted) {
+		t.Errorf("Unexpected summary: %v", summary)
+	}
+	if summary["total_events"] != float64(3) || summary["artifact_updates"] != float64(1) {
+		t.Errorf("Unexpected summary counts: %v", summary)
+	}
+}
+
 func TestSubmitStreamingTaskCmd_TaskSnapshot(t *testing.T) {
 	originalClient := a2aClient
 	originalLogger := logger
@@ -360,3 +490,203 @@ func TestSubmitStreamingTaskCmd_TaskSnapshot(t *testing.T) {
 		}
 	}
 }
+
+func TestCancelTaskCmd_SingleTask(t *testing.T) {
+	originalClient := a2aClient
+	originalLogger := logger
+	logger = zap.NewNop()
+
+	var cancelledID string
+	a2aClient = &mockA2AClient{
+		cancelTaskFunc: func(ctx context.Context, params adk.TaskIdParams) (*adk.JSONRPCSuccessResponse, error) {
+			cancelledID = params.ID
+			return &adk.JSONRPCSuccessResponse{
+				Result: map[string]any{
+					"id":        params.ID,
+					"contextId": "ctx-1",
+					"status":    map[string]any{"state": string(adk.TaskStateCancelled)},
+				},
+			}, nil
+		},
+	}
+
+	viper.Set("output", "json")
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("all-in-context", "", "")
+	cmd.Flags().String("state", "working", "")
+
+	err := cancelTaskCmd.RunE(cmd, []string{"task-1"})
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r)
+	output := buf.String()
+
+	a2aClient = originalClient
+	logger = originalLogger
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cancelledID != "task-1" {
+		t.Errorf("Expected task-1 to be cancelled, got %q", cancelledID)
+	}
+	if !strings.Contains(output, string(adk.TaskStateCancelled)) {
+		t.Errorf("Expected output to contain cancelled state, got:\n%s", output)
+	}
+}
+
+func TestCancelTaskCmd_AllInContext(t *testing.T) {
+	originalClient := a2aClient
+	originalLogger := logger
+	logger = zap.NewNop()
+
+	var listedState adk.TaskState
+	var cancelled []string
+	a2aClient = &mockA2AClient{
+		listTasksFunc: func(ctx context.Context, params adk.TaskListParams) (*adk.JSONRPCSuccessResponse, error) {
+			if params.State != nil {
+				listedState = *params.State
+			}
+			return &adk.JSONRPCSuccessResponse{
+				Result: map[string]any{
+					"tasks": []map[string]any{
+						{"id": "task-1", "contextId": "ctx-1", "status": map[string]any{"state": string(adk.TaskStateWorking)}},
+						{"id": "task-2", "contextId": "ctx-1", "status": map[string]any{"state": string(adk.TaskStateCompleted)}},
+						{"id": "task-3", "contextId": "ctx-1", "status": map[string]any{"state": string(adk.TaskStateWorking)}},
+					},
+				},
+			}, nil
+		},
+		cancelTaskFunc: func(ctx context.Context, params adk.TaskIdParams) (*adk.JSONRPCSuccessResponse, error) {
+			if params.ID == "task-3" {
+				return nil, fmt.Errorf("A2A error: Task cannot be canceled (code: -32002)")
+			}
+			cancelled = append(cancelled, params.ID)
+			return &adk.JSONRPCSuccessResponse{
+				Result: map[string]any{
+					"id":        params.ID,
+					"contextId": "ctx-1",
+					"status":    map[string]any{"state": string(adk.TaskStateCancelled)},
+				},
+			}, nil
+		},
+	}
+
+	viper.Set("output", "json")
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("all-in-context", "", "")
+	cmd.Flags().String("state", "working", "")
+	_ = cmd.Flag("all-in-context").Value.Set("ctx-1")
+
+	err := cancelTaskCmd.RunE(cmd, nil)
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r)
+	output := buf.String()
+
+	a2aClient = originalClient
+	logger = originalLogger
+
+	if err == nil {
+		t.Fatal("Expected an error for the task that could not be cancelled")
+	}
+	if listedState != adk.TaskStateWorking {
+		t.Errorf("Expected list filter %s, got %s", adk.TaskStateWorking, listedState)
+	}
+	if len(cancelled) != 1 || cancelled[0] != "task-1" {
+		t.Errorf("Expected only task-1 to be cancelled, got %v", cancelled)
+	}
+	if !strings.Contains(output, "Task cannot be canceled in its current state") {
+		t.Errorf("Expected friendly not-cancelable message in output, got:\n%s", output)
+	}
+}
+
+func TestResubscribeTaskCmd_StreamingSummary(t *testing.T) {
+	originalClient := a2aClient
+	originalLogger := logger
+	logger = zap.NewNop()
+
+	var resubscribedName string
+	a2aClient = &mockA2AClient{
+		resubscribeTaskFunc: func(ctx context.Context, params adk.TaskResubscriptionParams) (<-chan adk.JSONRPCSuccessResponse, error) {
+			resubscribedName = params.Name
+			ch := make(chan adk.JSONRPCSuccessResponse, 2)
+			ch <- adk.JSONRPCSuccessResponse{
+				Result: map[string]any{
+					"taskId":    "task-running",
+					"contextId": "ctx-running",
+					"artifact": map[string]any{
+						"artifactId": "artifact-1",
+						"parts":      []map[string]any{{"text": "partial"}},
+					},
+				},
+			}
+			ch <- adk.JSONRPCSuccessResponse{
+				Result: map[string]any{
+					"taskId":    "task-running",
+					"contextId": "ctx-running",
+					"final":     true,
+					"status":    map[string]any{"state": string(adk.TaskStateCompleted)},
+				},
+			}
+			close(ch)
+			return ch, nil
+		},
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("raw", false, "Show raw streaming event data")
+
+	err := resubscribeTaskCmd.RunE(cmd, []string{"task-running"})
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r)
+	output := buf.String()
+
+	a2aClient = originalClient
+	logger = originalLogger
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if resubscribedName != "task-running" {
+		t.Errorf("Expected resubscribe for task-running, got %q", resubscribedName)
+	}
+
+	expectedParts := []string{
+		"Artifact Update:",
+		"Status Update: " + string(adk.TaskStateCompleted),
+		"[FINAL]",
+		"Task ID: task-running",
+		"Context ID: ctx-running",
+		"Total Events: 2",
+		"Status Updates: 1",
+		"Artifact Updates: 1",
+	}
+
+	for _, part := range expectedParts {
+		if !strings.Contains(output, part) {
+			t.Errorf("Expected output to contain '%s', but it didn't.\nActual output:\n%s", part, output)
+		}
+	}
+}
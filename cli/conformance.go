@@ -0,0 +1,741 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	uuid "github.com/google/uuid"
+	cobra "github.com/spf13/cobra"
+	viper "github.com/spf13/viper"
+
+	client "github.com/inference-gateway/adk/client"
+	adk "github.com/inference-gateway/adk/types"
+)
+
+// Conformance check outcomes
+const (
+	conformancePass = "pass"
+	conformanceFail = "fail"
+	conformanceSkip = "skip"
+)
+
+// conformanceWebhookURL is registered by the push config round-trip check. It is
+// never called because the check deletes the config again right away.
+const conformanceWebhookURL = "https://a2a-conformance.invalid/webhook"
+
+// conformanceTaskPollInterval is how often tasks/get is polled while waiting for a task to finish
+var conformanceTaskPollInterval = 500 * time.Millisecond
+
+// conformanceCheck is a single check of the conformance catalog. Run returns a
+// detail message on success, an error on failure, or a skipError when the check
+// does not apply to the server.
+type conformanceCheck struct {
+	ID          string
+	Description string
+	Run         func(ctx context.Context, r *conformanceRun) (string, error)
+}
+
+// skipError marks a check as skipped
+type skipError struct {
+	reason string
+}
+
+func (e skipError) Error() string {
+	return e.reason
+}
+
+// skipf skips a check with a formatted reason
+func skipf(format string, args ...any) error {
+	return skipError{reason: fmt.Sprintf(format, args...)}
+}
+
+// conformanceResult is the outcome of a single check
+type conformanceResult struct {
+	ID          string        `json:"id" yaml:"id"`
+	Description string        `json:"description" yaml:"description"`
+	Status      string        `json:"status" yaml:"status"`
+	Message     string        `json:"message,omitempty" yaml:"message,omitempty"`
+	Duration    time.Duration `json:"-" yaml:"-"`
+	DurationMs  int64         `json:"duration_ms" yaml:"duration_ms"`
+}
+
+// conformanceReport is the outcome of a conformance run
+type conformanceReport struct {
+	ServerURL string              `json:"server_url" yaml:"server_url"`
+	Passed    int                 `json:"passed" yaml:"passed"`
+	Failed    int                 `json:"failed" yaml:"failed"`
+	Skipped   int                 `json:"skipped" yaml:"skipped"`
+	Results   []conformanceResult `json:"results" yaml:"results"`
+}
+
+// conformanceRun holds the connection to the server under test and the state
+// shared between checks, such as the agent card and the task created by message/send
+type conformanceRun struct {
+	client     client.A2AClient
+	httpClient *http.Client
+	serverURL  string
+
+	card     *adk.AgentCard
+	cardJSON []byte
+	task     *adk.Task
+}
+
+// conformanceChecks is the catalog of checks in the order they run
+var conformanceChecks = []conformanceCheck{
+	{ID: "card.fetch", Description: "Agent card is served at /.well-known/agent-card.json", Run: checkCardFetch},
+	{ID: "card.valid", Description: "Agent card passes schema and lint validation without errors", Run: checkCardValid},
+	{ID: "jsonrpc.parse-error", Description: "Malformed JSON is rejected with -32700", Run: checkParseError},
+	{ID: "jsonrpc.method-not-found", Description: "Unknown methods are rejected with -32601", Run: checkMethodNotFound},
+	{ID: "jsonrpc.invalid-params", Description: "message/send without a message is rejected with -32602", Run: checkInvalidParams},
+	{ID: "message.send", Description: "message/send returns a task with a valid state or an agent message", Run: checkMessageSend},
+	{ID: "task.lifecycle", Description: "The task reaches a terminal or interrupted state", Run: checkTaskLifecycle},
+	{ID: "tasks.get", Description: "tasks/get returns the task created by message/send", Run: checkTasksGet},
+	{ID: "tasks.get.history-length", Description: "tasks/get limits history to historyLength messages", Run: checkHistoryLength},
+	{ID: "tasks.get.not-found", Description: "tasks/get for an unknown task is rejected with -32001", Run: checkTaskNotFound},
+	{ID: "tasks.cancel.not-cancelable", Description: "Canceling a finished task is rejected with -32002", Run: checkCancelFinished},
+	{ID: "tasks.cancel.not-found", Description: "Canceling an unknown task is rejected with -32001", Run: checkCancelNotFound},
+	{ID: "message.stream", Description: "message/stream emits events for one task ending with a single final status update", Run: checkStreaming},
+	{ID: "push-config.round-trip", Description: "Push notification configs can be set, read, listed and deleted", Run: checkPushConfigRoundTrip},
+}
+
+// runConformance runs the selected checks in catalog order. A check is selected
+// when only is empty or its ID starts with one of the given prefixes.
+func runConformance(ctx context.Context, r *conformanceRun, only []string) conformanceReport {
+	report := conformanceReport{ServerURL: r.serverURL, Results: []conformanceResult{}}
+
+	for _, check := range conformanceChecks {
+		if !conformanceSelected(check.ID, only) {
+			continue
+		}
+
+		start := time.Now()
+		message, err := check.Run(ctx, r)
+		result := conformanceResult{
+			ID:          check.ID,
+			Description: check.Description,
+			Status:      conformancePass,
+			Message:     message,
+			Duration:    time.Since(start),
+		}
+		result.DurationMs = result.Duration.Milliseconds()
+
+		var skip skipError
+		switch {
+		case errors.As(err, &skip):
+			result.Status = conformanceSkip
+			result.Message = skip.reason
+			report.Skipped++
+		case err != nil:
+			result.Status = conformanceFail
+			result.Message = err.Error()
+			report.Failed++
+		default:
+			report.Passed++
+		}
+
+		report.Results = append(report.Results, result)
+	}
+
+	return report
+}
+
+// conformanceSelected reports whether a check ID matches one of the --only prefixes
+func conformanceSelected(id string, only []string) bool {
+	if len(only) == 0 {
+		return true
+	}
+	for _, prefix := range only {
+		if prefix != "" && strings.HasPrefix(id, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// requireCard skips a check when the agent card could not be fetched
+func (r *conformanceRun) requireCard() error {
+	if r.card == nil {
+		return skipf("agent card unavailable")
+	}
+	return nil
+}
+
+// requireTask skips a check when message/send did not create a task
+func (r *conformanceRun) requireTask() error {
+	if r.task == nil {
+		return skipf("message/send did not create a task")
+	}
+	return nil
+}
+
+func checkCardFetch(ctx context.Context, r *conformanceRun) (string, error) {
+	body, err := fetchAgentCardJSON(ctx, r.httpClient, r.serverURL)
+	if err != nil {
+		return "", err
+	}
+
+	var card adk.AgentCard
+	if err := json.Unmarshal(body, &card); err != nil {
+		return "", fmt.Errorf("agent card is not valid JSON: %w", err)
+	}
+
+	r.card = &card
+	r.cardJSON = body
+	return fmt.Sprintf("%s %s", card.Name, card.Version), nil
+}
+
+func checkCardValid(ctx context.Context, r *conformanceRun) (string, error) {
+	if err := r.requireCard(); err != nil {
+		return "", err
+	}
+
+	var errs []string
+	warnings := 0
+	for _, finding := range validateAgentCard(r.cardJSON) {
+		switch finding.Severity {
+		case severityError:
+			errs = append(errs, finding.Message)
+		case severityWarning:
+			warnings++
+		}
+	}
+
+	if len(errs) > 0 {
+		return "", fmt.Errorf("%d error(s): %s", len(errs), strings.Join(errs, "; "))
+	}
+	return fmt.Sprintf("%d warning(s)", warnings), nil
+}
+
+func checkParseError(ctx context.Context, r *conformanceRun) (string, error) {
+	resp, err := postJSONRPC(ctx, r.httpClient, r.serverURL, []byte(`{"jsonrpc":"2.0","id":1,"method":`))
+	if err != nil {
+		return "", err
+	}
+	return expectJSONRPCError(resp, jsonRPCParseError)
+}
+
+func checkMethodNotFound(ctx context.Context, r *conformanceRun) (string, error) {
+	resp, err := callJSONRPC(ctx, r.httpClient, r.serverURL, "conformance/unknownMethod", map[string]any{})
+	if err != nil {
+		return "", err
+	}
+	return expectJSONRPCError(resp, jsonRPCMethodNotFound)
+}
+
+func checkInvalidParams(ctx context.Context, r *conformanceRun) (string, error) {
+	resp, err := callJSONRPC(ctx, r.httpClient, r.serverURL, "message/send", map[string]any{"message": "not a message"})
+	if err != nil {
+		return "", err
+	}
+	return expectJSONRPCError(resp, jsonRPCInvalidParams)
+}
+
+func checkMessageSend(ctx context.Context, r *conformanceRun) (string, error) {
+	resp, err := r.client.SendTask(ctx, conformanceMessage("Hello from the A2A conformance suite"))
+	if err != nil {
+		return "", err
+	}
+
+	eventJSON, kind, err := classifyStreamEvent(resp.Result)
+	if err != nil {
+		return "", err
+	}
+
+	if kind != eventKindTask {
+		var message adk.Message
+		if err := json.Unmarshal(eventJSON, &message); err == nil && message.MessageID != "" {
+			if message.Role != adk.RoleAgent {
+				return "", fmt.Errorf("reply message has role %q, expected %q", message.Role, adk.RoleAgent)
+			}
+			return "agent replied with a message without creating a task", nil
+		}
+		return "", fmt.Errorf("result is neither a task nor a message: %s", previewText(string(eventJSON), widePreviewLength))
+	}
+
+	var task adk.Task
+	if err := json.Unmarshal(eventJSON, &task); err != nil {
+		return "", fmt.Errorf("failed to decode task: %w", err)
+	}
+	if task.ContextID == "" {
+		return "", fmt.Errorf("task %s has no contextId", task.ID)
+	}
+	if !task.Status.State.Valid() || task.Status.State == adk.TaskStateUnspecified {
+		return "", fmt.Errorf("task %s has invalid state %q", task.ID, task.Status.State)
+	}
+
+	r.task = &task
+	return fmt.Sprintf("task %s is %s", shortID(task.ID), humanState(task.Status.State)), nil
+}
+
+func checkTaskLifecycle(ctx context.Context, r *conformanceRun) (string, error) {
+	if err := r.requireTask(); err != nil {
+		return "", err
+	}
+
+	states := []string{humanState(r.task.Status.State)}
+	for !isTerminalState(r.task.Status.State) && r.task.Status.State != adk.TaskStateAuthRequired {
+		if !sleepContext(ctx, conformanceTaskPollInterval) {
+			return "", fmt.Errorf("task %s still %s when the run timed out", shortID(r.task.ID), humanState(r.task.Status.State))
+		}
+
+		resp, err := r.client.GetTask(ctx, adk.TaskQueryParams{ID: r.task.ID})
+		if err != nil {
+			return "", fmt.Errorf("tasks/get failed: %w", err)
+		}
+		task, err := taskFromResult(resp.Result)
+		if err != nil {
+			return "", err
+		}
+		if state := humanState(task.Status.State); state != states[len(states)-1] {
+			states = append(states, state)
+		}
+		r.task = &task
+	}
+
+	return strings.Join(states, " → "), nil
+}
+
+func checkTasksGet(ctx context.Context, r *conformanceRun) (string, error) {
+	if err := r.requireTask(); err != nil {
+		return "", err
+	}
+
+	resp, err := r.client.GetTask(ctx, adk.TaskQueryParams{ID: r.task.ID})
+	if err != nil {
+		return "", err
+	}
+	task, err := taskFromResult(resp.Result)
+	if err != nil {
+		return "", err
+	}
+
+	if task.ID != r.task.ID {
+		return "", fmt.Errorf("returned task %q, expected %q", task.ID, r.task.ID)
+	}
+	if task.ContextID != r.task.ContextID {
+		return "", fmt.Errorf("returned contextId %q, expected %q", task.ContextID, r.task.ContextID)
+	}
+	return fmt.Sprintf("%d history message(s)", len(task.History)), nil
+}
+
+func checkHistoryLength(ctx context.Context, r *conformanceRun) (string, error) {
+	if err := r.requireTask(); err != nil {
+		return "", err
+	}
+
+	historyLength := 1
+	resp, err := r.client.GetTask(ctx, adk.TaskQueryParams{ID: r.task.ID, HistoryLength: &historyLength})
+	if err != nil {
+		return "", err
+	}
+	task, err := taskFromResult(resp.Result)
+	if err != nil {
+		return "", err
+	}
+
+	if len(task.History) > historyLength {
+		return "", fmt.Errorf("historyLength=%d returned %d message(s)", historyLength, len(task.History))
+	}
+	return fmt.Sprintf("historyLength=%d returned %d message(s)", historyLength, len(task.History)), nil
+}
+
+func checkTaskNotFound(ctx context.Context, r *conformanceRun) (string, error) {
+	resp, err := callJSONRPC(ctx, r.httpClient, r.serverURL, "tasks/get", adk.TaskQueryParams{ID: "conformance-" + uuid.NewString()})
+	if err != nil {
+		return "", err
+	}
+	return expectJSONRPCError(resp, jsonRPCTaskNotFound)
+}
+
+func checkCancelFinished(ctx context.Context, r *conformanceRun) (string, error) {
+	if err := r.requireTask(); err != nil {
+		return "", err
+	}
+	switch r.task.Status.State {
+	case adk.TaskStateCompleted, adk.TaskStateFailed, adk.TaskStateCancelled, adk.TaskStateRejected:
+	default:
+		return "", skipf("task is %s, not in a final state", humanState(r.task.Status.State))
+	}
+
+	resp, err := callJSONRPC(ctx, r.httpClient, r.serverURL, "tasks/cancel", adk.TaskIdParams{ID: r.task.ID})
+	if err != nil {
+		return "", err
+	}
+	return expectJSONRPCError(resp, jsonRPCTaskNotCancelable)
+}
+
+func checkCancelNotFound(ctx context.Context, r *conformanceRun) (string, error) {
+	resp, err := callJSONRPC(ctx, r.httpClient, r.serverURL, "tasks/cancel", adk.TaskIdParams{ID: "conformance-" + uuid.NewString()})
+	if err != nil {
+		return "", err
+	}
+	return expectJSONRPCError(resp, jsonRPCTaskNotFound)
+}
+
+func checkStreaming(ctx context.Context, r *conformanceRun) (string, error) {
+	if err := r.requireCard(); err != nil {
+		return "", err
+	}
+	if r.card.Capabilities.Streaming == nil || !*r.card.Capabilities.Streaming {
+		return "", skipf("agent card does not advertise streaming")
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events, err := r.client.SendTaskStreaming(streamCtx, conformanceMessage("Hello from the A2A conformance suite (streaming)"))
+	if err != nil {
+		return "", err
+	}
+
+	var summary streamingSummary
+	finalAt := 0
+	for resp := range events {
+		summary.TotalEvents++
+
+		if resp.Result == nil {
+			return "", fmt.Errorf("event %d has no result", summary.TotalEvents)
+		}
+		eventJSON, kind, err := classifyStreamEvent(resp.Result)
+		if err != nil {
+			return "", err
+		}
+		if kind == "" {
+			return "", fmt.Errorf("event %d is not a task, status update or artifact update", summary.TotalEvents)
+		}
+		if finalAt > 0 {
+			return "", fmt.Errorf("event %d (%s) was sent after the final status update", summary.TotalEvents, kind)
+		}
+
+		taskID := summary.TaskID
+		summary.record(kind, eventJSON)
+		if taskID != "" && summary.TaskID != taskID {
+			return "", fmt.Errorf("event %d belongs to task %s, expected %s", summary.TotalEvents, summary.TaskID, taskID)
+		}
+
+		if kind == eventKindStatusUpdate {
+			var status adk.TaskStatusUpdateEvent
+			if err := json.Unmarshal(eventJSON, &status); err == nil && status.Final {
+				finalAt = summary.TotalEvents
+			}
+		}
+	}
+
+	if summary.TotalEvents == 0 {
+		return "", fmt.Errorf("stream ended without events")
+	}
+	if finalAt == 0 {
+		return "", fmt.Errorf("stream ended after %d event(s) without a status update marked final", summary.TotalEvents)
+	}
+	return fmt.Sprintf("%d event(s), final state %s", summary.TotalEvents, humanState(adk.TaskState(summary.FinalStatus))), nil
+}
+
+func checkPushConfigRoundTrip(ctx context.Context, r *conformanceRun) (string, error) {
+	if err := r.requireCard(); err != nil {
+		return "", err
+	}
+	if r.card.Capabilities.PushNotifications == nil || !*r.card.Capabilities.PushNotifications {
+		return "", skipf("agent card does not advertise push notifications")
+	}
+	if err := r.requireTask(); err != nil {
+		return "", err
+	}
+
+	token := "conformance-" + uuid.NewString()
+	if _, err := r.client.SetTaskPushNotificationConfig(ctx, adk.TaskPushNotificationConfig{
+		Name:                   r.task.ID,
+		PushNotificationConfig: adk.PushNotificationConfig{URL: conformanceWebhookURL, Token: &token},
+	}); err != nil {
+		return "", fmt.Errorf("set failed: %w", err)
+	}
+
+	resp, err := r.client.GetTaskPushNotificationConfig(ctx, adk.GetTaskPushNotificationConfigParams{Name: r.task.ID})
+	if err != nil {
+		return "", fmt.Errorf("get failed: %w", err)
+	}
+	var config adk.TaskPushNotificationConfig
+	if err := decodeResult(resp.Result, &config); err != nil {
+		return "", err
+	}
+	if config.PushNotificationConfig.URL != conformanceWebhookURL {
+		return "", fmt.Errorf("get returned URL %q, expected %q", config.PushNotificationConfig.URL, conformanceWebhookURL)
+	}
+
+	resp, err = r.client.ListTaskPushNotificationConfig(ctx, adk.ListTaskPushNotificationConfigParams{Parent: r.task.ID})
+	if err != nil {
+		return "", fmt.Errorf("list failed: %w", err)
+	}
+	list, err := pushConfigListFromResult(resp.Result)
+	if err != nil {
+		return "", err
+	}
+	found := false
+	for _, listed := range list.Configs {
+		if listed.PushNotificationConfig.URL == conformanceWebhookURL {
+			found = true
+		}
+	}
+	if !found {
+		return "", fmt.Errorf("list does not include the config that was set")
+	}
+
+	if _, err := r.client.DeleteTaskPushNotificationConfig(ctx, adk.DeleteTaskPushNotificationConfigParams{Name: r.task.ID}); err != nil {
+		return "", fmt.Errorf("delete failed: %w", err)
+	}
+	return "set, get, list and delete succeeded", nil
+}
+
+// conformanceMessage builds the user message sent by the lifecycle and streaming checks
+func conformanceMessage(text string) adk.MessageSendParams {
+	return adk.MessageSendParams{
+		Message: adk.Message{
+			MessageID: uuid.NewString(),
+			Role:      adk.RoleUser,
+			Parts:     []adk.Part{{Text: &text}},
+		},
+	}
+}
+
+// jsonRPCResponse is a JSON-RPC response carrying either a result or an error
+type jsonRPCResponse struct {
+	JSONRPC string            `json:"jsonrpc"`
+	ID      any               `json:"id"`
+	Result  json.RawMessage   `json:"result,omitempty"`
+	Error   *adk.JSONRPCError `json:"error,omitempty"`
+}
+
+// a2aEndpointURL returns the JSON-RPC endpoint of a server, following the same rules as the A2A client
+func a2aEndpointURL(serverURL string) string {
+	if strings.HasSuffix(serverURL, "/a2a") {
+		return serverURL
+	}
+	return strings.TrimRight(serverURL, "/") + "/a2a"
+}
+
+// callJSONRPC sends a JSON-RPC request and decodes the response without interpreting errors
+func callJSONRPC(ctx context.Context, httpClient *http.Client, serverURL, method string, params any) (*jsonRPCResponse, error) {
+	body, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      uuid.NewString(),
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	return postJSONRPC(ctx, httpClient, serverURL, body)
+}
+
+// postJSONRPC posts a raw JSON-RPC request body to the server
+func postJSONRPC(ctx context.Context, httpClient *http.Client, serverURL string, body []byte) (*jsonRPCResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a2aEndpointURL(serverURL), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var decoded jsonRPCResponse
+	if err := json.Unmarshal(respBody, &decoded); err != nil {
+		return nil, fmt.Errorf("HTTP %d response is not JSON-RPC: %s", resp.StatusCode, previewText(string(respBody), widePreviewLength))
+	}
+	return &decoded, nil
+}
+
+// expectJSONRPCError checks that a response is a JSON-RPC error with the given code
+func expectJSONRPCError(resp *jsonRPCResponse, code int) (string, error) {
+	if resp.Error == nil {
+		return "", fmt.Errorf("expected error %d, got a result", code)
+	}
+	if resp.Error.Code != code {
+		return "", fmt.Errorf("expected error %d, got %d (%s)", code, resp.Error.Code, resp.Error.Message)
+	}
+	return fmt.Sprintf("%d %s", resp.Error.Code, resp.Error.Message), nil
+}
+
+// conformanceTable lists the check results of a report
+func conformanceTable(report conformanceReport, wide bool) tableView {
+	view := tableView{Headers: []string{"CHECK", "STATUS", "MESSAGE"}}
+	if wide {
+		view.Headers = append(view.Headers, "DURATION", "DESCRIPTION")
+	}
+
+	for _, result := range report.Results {
+		previewLength := tablePreviewLength
+		if wide {
+			previewLength = widePreviewLength
+		}
+
+		row := []string{result.ID, strings.ToUpper(result.Status), previewText(result.Message, previewLength)}
+		if wide {
+			row = append(row, result.Duration.Round(time.Millisecond).String(), result.Description)
+		}
+		view.Rows = append(view.Rows, row)
+	}
+
+	return view
+}
+
+// JUnit XML report elements
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+}
+
+// renderJUnitReport renders a conformance report as JUnit XML
+func renderJUnitReport(report conformanceReport) ([]byte, error) {
+	suite := junitTestSuite{
+		Name:     "a2a-conformance " + report.ServerURL,
+		Tests:    len(report.Results),
+		Failures: report.Failed,
+		Skipped:  report.Skipped,
+	}
+
+	var total time.Duration
+	for _, result := range report.Results {
+		total += result.Duration
+
+		classname, _, _ := strings.Cut(result.ID, ".")
+		testCase := junitTestCase{
+			Name:      result.ID,
+			ClassName: "a2a." + classname,
+			Time:      fmt.Sprintf("%.3f", result.Duration.Seconds()),
+		}
+		switch result.Status {
+		case conformanceFail:
+			testCase.Failure = &junitMessage{Message: result.Message}
+		case conformanceSkip:
+			testCase.Skipped = &junitMessage{Message: result.Message}
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+	suite.Time = fmt.Sprintf("%.3f", total.Seconds())
+
+	output, err := xml.MarshalIndent(junitTestSuites{Suites: []junitTestSuite{suite}}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to render JUnit report: %w", err)
+	}
+	return append([]byte(xml.Header), append(output, '\n')...), nil
+}
+
+// Conformance namespace command
+var conformanceCmd = &cobra.Command{
+	Use:   "conformance",
+	Short: "A2A protocol conformance commands",
+	Long:  "Commands for checking how closely an A2A server follows the protocol specification.",
+}
+
+var conformanceRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Run the conformance checks against the A2A server",
+	Long: `Runs a catalog of protocol checks against the A2A server and reports each one
+as pass, fail or skip: agent card well-formedness, JSON-RPC error codes, the
+message/send task lifecycle, tasks/get and tasks/cancel behaviour, streaming event
+ordering and push notification config round-trips.
+
+Checks for capabilities the agent card does not advertise are skipped. The report
+is printed in the configured output format (use -o table for a summary) and can
+also be written as JUnit XML with --junit. The command exits with a non-zero status
+when a check fails.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		only, _ := cmd.Flags().GetStringSlice("only")
+		junitPath, _ := cmd.Flags().GetString("junit")
+		maxDuration, _ := cmd.Flags().GetDuration("max-duration")
+
+		ensureA2AClient()
+
+		ctx, cancel := context.WithTimeout(context.Background(), maxDuration)
+		defer cancel()
+
+		report := runConformance(ctx, &conformanceRun{
+			client:     a2aClient,
+			httpClient: a2aHTTPClient,
+			serverURL:  viper.GetString("server-url"),
+		}, only)
+
+		if junitPath != "" {
+			junit, err := renderJUnitReport(report)
+			if err != nil {
+				return err
+			}
+			if junitPath == "-" {
+				fmt.Print(string(junit))
+			} else if err := os.WriteFile(junitPath, junit, 0o644); err != nil {
+				return fmt.Errorf("failed to write JUnit report: %w", err)
+			}
+		}
+
+		if junitPath != "-" {
+			if err := printFormattedTable(report, func(wide bool) tableView {
+				return conformanceTable(report, wide)
+			}); err != nil {
+				return err
+			}
+		}
+
+		if report.Failed > 0 {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("%d of %d conformance check(s) failed", report.Failed, len(report.Results))
+		}
+		return nil
+	},
+}
+
+var conformanceListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the conformance checks",
+	Long:  "Lists the checks run by conformance run, in the order they run.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		checks := make([]map[string]string, 0, len(conformanceChecks))
+		view := tableView{Headers: []string{"CHECK", "DESCRIPTION"}}
+		for _, check := range conformanceChecks {
+			checks = append(checks, map[string]string{"id": check.ID, "description": check.Description})
+			view.Rows = append(view.Rows, []string{check.ID, check.Description})
+		}
+
+		return printFormattedTable(map[string]any{"checks": checks}, func(wide bool) tableView {
+			return view
+		})
+	},
+}
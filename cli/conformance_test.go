@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	client "github.com/inference-gateway/adk/client"
+	"go.uber.org/zap"
+)
+
+func newConformanceRun(t *testing.T, handler http.Handler) *conformanceRun {
+	t.Helper()
+
+	originalLogger := logger
+	logger = zap.NewNop()
+	t.Cleanup(func() { logger = originalLogger })
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return &conformanceRun{
+		client:     client.NewClientWithLogger(server.URL, zap.NewNop()),
+		httpClient: server.Client(),
+		serverURL:  server.URL,
+	}
+}
+
+func resultsByID(report conformanceReport) map[string]conformanceResult {
+	results := map[string]conformanceResult{}
+	for _, result := range report.Results {
+		results[result.ID] = result
+	}
+	return results
+}
+
+func TestRunConformance_MockServer(t *testing.T) {
+	scenario, err := loadMockScenario("")
+	if err != nil {
+		t.Fatalf("loadMockScenario failed: %v", err)
+	}
+
+	run := newConformanceRun(t, newMockServer(scenario, io.Discard))
+	report := runConformance(context.Background(), run, nil)
+
+	if len(report.Results) != len(conformanceChecks) {
+		t.Fatalf("Expected every check to run, got %d results", len(report.Results))
+	}
+	for _, result := range report.Results {
+		if result.Status == conformanceFail {
+			t.Errorf("Expected %s to pass against the mock server, got: %s", result.ID, result.Message)
+		}
+	}
+
+	push := resultsByID(report)["push-config.round-trip"]
+	if push.Status != conformanceSkip || !strings.Contains(push.Message, "push notifications") {
+		t.Errorf("Expected the push config check to be skipped, got %+v", push)
+	}
+	if report.Passed != len(conformanceChecks)-1 || report.Skipped != 1 {
+		t.Errorf("Unexpected totals: passed=%d skipped=%d failed=%d", report.Passed, report.Skipped, report.Failed)
+	}
+}
+
+func TestRunConformance_NonConformingServer(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/.well-known/agent-card.json" {
+			_, _ = w.Write([]byte(`{"name":"Sloppy","version":"1.0.0","capabilities":{"streaming":false}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{}}`))
+	})
+
+	run := newConformanceRun(t, handler)
+	report := runConformance(context.Background(), run, []string{"card", "jsonrpc", "message", "tasks.get"})
+	results := resultsByID(report)
+
+	for _, id := range []string{"card.valid", "jsonrpc.parse-error", "jsonrpc.method-not-found", "jsonrpc.invalid-params", "message.send", "tasks.get.not-found"} {
+		if results[id].Status != conformanceFail {
+			t.Errorf("Expected %s to fail, got %+v", id, results[id])
+		}
+	}
+	if results["card.fetch"].Status != conformancePass {
+		t.Errorf("Expected the card to be fetched, got %+v", results["card.fetch"])
+	}
+	if results["message.stream"].Status != conformanceSkip {
+		t.Errorf("Expected streaming to be skipped when the card does not advertise it, got %+v", results["message.stream"])
+	}
+	if results["tasks.get"].Status != conformanceSkip {
+		t.Errorf("Expected tasks.get to be skipped without a task, got %+v", results["tasks.get"])
+	}
+	if _, ok := results["tasks.cancel.not-found"]; ok {
+		t.Error("Expected checks outside --only to be left out")
+	}
+}
+
+func TestRenderJUnitReport(t *testing.T) {
+	report := conformanceReport{
+		ServerURL: "http://localhost:8080",
+		Passed:    1,
+		Failed:    1,
+		Skipped:   1,
+		Results: []conformanceResult{
+			{ID: "card.fetch", Status: conformancePass, Duration: 15 * time.Millisecond},
+			{ID: "jsonrpc.method-not-found", Status: conformanceFail, Message: "expected error -32601, got a result"},
+			{ID: "message.stream", Status: conformanceSkip, Message: "agent card does not advertise streaming"},
+		},
+	}
+
+	output, err := renderJUnitReport(report)
+	if err != nil {
+		t.Fatalf("renderJUnitReport failed: %v", err)
+	}
+
+	var parsed junitTestSuites
+	if err := xml.Unmarshal(output, &parsed); err != nil {
+		t.Fatalf("Expected valid XML, got %v:\n%s", err, output)
+	}
+	suite := parsed.Suites[0]
+	if suite.Tests != 3 || suite.Failures != 1 || suite.Skipped != 1 {
+		t.Errorf("Unexpected suite totals: %+v", suite)
+	}
+	if suite.Cases[0].ClassName != "a2a.card" || suite.Cases[0].Time != "0.015" {
+		t.Errorf("Unexpected test case: %+v", suite.Cases[0])
+	}
+	if suite.Cases[1].Failure == nil || suite.Cases[1].Failure.Message != "expected error -32601, got a result" {
+		t.Errorf("Expected a failure element, got %+v", suite.Cases[1])
+	}
+	if suite.Cases[2].Skipped == nil {
+		t.Errorf("Expected a skipped element, got %+v", suite.Cases[2])
+	}
+}
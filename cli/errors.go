@@ -0,0 +1,364 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	adk "github.com/inference-gateway/adk/types"
+)
+
+// JSON-RPC error codes defined by JSON-RPC 2.0 and the A2A protocol
+const (
+	jsonRPCParseError                   = -32700
+	jsonRPCInvalidRequest               = -32600
+	jsonRPCMethodNotFound               = -32601
+	jsonRPCInvalidParams                = -32602
+	jsonRPCInternalError                = -32603
+	jsonRPCTaskNotFound                 = -32001
+	jsonRPCTaskNotCancelable            = -32002
+	jsonRPCPushNotificationNotSupported = -32003
+	jsonRPCUnsupportedOperation         = -32004
+	jsonRPCContentTypeNotSupported      = -32005
+	jsonRPCInvalidAgentResponse         = -32006
+)
+
+// Process exit codes. JSON-RPC errors with a known code exit with the code of
+// their kind, other JSON-RPC errors with exitCodeJSONRPCError.
+const (
+	exitCodeError        = 1
+	exitCodeJSONRPCError = 15
+)
+
+// jsonRPCErrorKind describes how errors with a JSON-RPC error code are presented
+type jsonRPCErrorKind struct {
+	Name     string
+	Summary  string
+	Hint     string
+	ExitCode int
+}
+
+// jsonRPCErrorKinds maps the known JSON-RPC error codes to their presentation
+var jsonRPCErrorKinds = map[int]jsonRPCErrorKind{
+	jsonRPCParseError: {
+		Name:     "JSONParseError",
+		Summary:  "The agent could not parse the request as JSON",
+		Hint:     "Check the params of raw requests, and any proxy that rewrites request bodies",
+		ExitCode: 10,
+	},
+	jsonRPCInvalidRequest: {
+		Name:     "InvalidRequestError",
+		Summary:  "The agent rejected the request as invalid JSON-RPC",
+		Hint:     "The agent may implement another version of the protocol; 'a2a conformance run' reports the mismatches",
+		ExitCode: 11,
+	},
+	jsonRPCMethodNotFound: {
+		Name:     "MethodNotFoundError",
+		Summary:  "Method not implemented by the agent",
+		Hint:     "Check the capabilities advertised by 'a2a agent-card'; the agent does not support this operation",
+		ExitCode: 12,
+	},
+	jsonRPCInvalidParams: {
+		Name:     "InvalidParamsError",
+		Summary:  "The agent rejected the request parameters",
+		Hint:     "Check the IDs and flags passed to the command; the error data may name the offending field",
+		ExitCode: 13,
+	},
+	jsonRPCInternalError: {
+		Name:     "InternalError",
+		Summary:  "The agent failed with an internal error",
+		Hint:     "This is a failure on the server side; check the agent logs and retry",
+		ExitCode: 14,
+	},
+	jsonRPCTaskNotFound: {
+		Name:     "TaskNotFoundError",
+		Summary:  "Task not found on the agent",
+		Hint:     "Check the task ID with 'a2a tasks list'; the agent may have expired the task",
+		ExitCode: 20,
+	},
+	jsonRPCTaskNotCancelable: {
+		Name:     "TaskNotCancelableError",
+		Summary:  "Task cannot be canceled in its current state",
+		Hint:     "Tasks that reached a terminal state cannot be canceled; check the state with 'a2a tasks get'",
+		ExitCode: 21,
+	},
+	jsonRPCPushNotificationNotSupported: {
+		Name:     "PushNotificationNotSupportedError",
+		Summary:  "Push notifications are not supported by the agent",
+		Hint:     "Check capabilities.pushNotifications in 'a2a agent-card', or poll the task with 'a2a tasks get' instead",
+		ExitCode: 22,
+	},
+	jsonRPCUnsupportedOperation: {
+		Name:     "UnsupportedOperationError",
+		Summary:  "The operation is not supported by the agent",
+		Hint:     "The agent implements the method but not this use of it; check the capabilities advertised by 'a2a agent-card'",
+		ExitCode: 23,
+	},
+	jsonRPCContentTypeNotSupported: {
+		Name:     "ContentTypeNotSupportedError",
+		Summary:  "The agent does not accept the content type of the message",
+		Hint:     "Compare the MIME types of the message parts with the input modes advertised by 'a2a agent-card'",
+		ExitCode: 24,
+	},
+	jsonRPCInvalidAgentResponse: {
+		Name:     "InvalidAgentResponseError",
+		Summary:  "The agent produced an invalid response",
+		Hint:     "This is a bug in the agent; 'a2a conformance run' and --trace-http help to pin it down",
+		ExitCode: 25,
+	},
+}
+
+// a2aClientErrorPattern extracts the message and code from errors returned by the A2A client
+var a2aClientErrorPattern = regexp.MustCompile(`A2A error: (.*) \(code: (-?\d+)\)`)
+
+// httpStatusErrorPattern extracts the status code and, when the client kept it, the body
+// from the errors the A2A client returns for responses other than 200 OK
+var httpStatusErrorPattern = regexp.MustCompile(`(?s)unexpected status code: (\d+)(?:, body: (.*))?`)
+
+// a2aError is a JSON-RPC error returned by the agent for a method call
+type a2aError struct {
+	Method  string
+	Code    int
+	Message string
+	Data    any
+
+	// printed is set when the error object is already part of the command output
+	printed bool
+}
+
+// kind returns the presentation of the error code, with a generic summary for unknown codes
+func (e *a2aError) kind() jsonRPCErrorKind {
+	if kind, ok := jsonRPCErrorKinds[e.Code]; ok {
+		return kind
+	}
+	return jsonRPCErrorKind{
+		Summary:  fmt.Sprintf("The agent returned JSON-RPC error %d", e.Code),
+		ExitCode: exitCodeJSONRPCError,
+	}
+}
+
+// summary explains the error in a sentence
+func (e *a2aError) summary() string {
+	if e.Code == jsonRPCMethodNotFound {
+		method := e.Method
+		if method == "" {
+			method = "method"
+		}
+		return fmt.Sprintf("Method '%s' not implemented by the agent", method)
+	}
+	return e.kind().Summary
+}
+
+// Error returns the summary followed by the code and message returned by the agent
+func (e *a2aError) Error() string {
+	if e.Message == "" {
+		return "❌ " + e.summary()
+	}
+	return fmt.Sprintf("❌ %s (%d: %s)", e.summary(), e.Code, e.Message)
+}
+
+// Hint suggests how to resolve the error
+func (e *a2aError) Hint() string {
+	return e.kind().Hint
+}
+
+// ExitCode returns the process exit code for the error
+func (e *a2aError) ExitCode() int {
+	return e.kind().ExitCode
+}
+
+// errorReport is the structured form of a failure for the json and ndjson output formats
+type errorReport struct {
+	Error errorDetail `json:"error" yaml:"error"`
+}
+
+// errorDetail describes a failure. The JSON-RPC fields are only set for errors returned by the agent.
+type errorDetail struct {
+	Method   string `json:"method,omitempty" yaml:"method,omitempty"`
+	Code     int    `json:"code,omitempty" yaml:"code,omitempty"`
+	Name     string `json:"name,omitempty" yaml:"name,omitempty"`
+	Message  string `json:"message" yaml:"message"`
+	Data     any    `json:"data,omitempty" yaml:"data,omitempty"`
+	Summary  string `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Hint     string `json:"hint,omitempty" yaml:"hint,omitempty"`
+	ExitCode int    `json:"exit_code" yaml:"exit_code"`
+}
+
+// newErrorReport builds the structured form of an error
+func newErrorReport(err error) errorReport {
+	var rpcErr *a2aError
+	if !errors.As(err, &rpcErr) {
+		return errorReport{Error: errorDetail{Message: err.Error(), ExitCode: exitCodeError}}
+	}
+	return errorReport{Error: errorDetail{
+		Method:   rpcErr.Method,
+		Code:     rpcErr.Code,
+		Name:     rpcErr.kind().Name,
+		Message:  rpcErr.Message,
+		Data:     rpcErr.Data,
+		Summary:  rpcErr.summary(),
+		Hint:     rpcErr.Hint(),
+		ExitCode: rpcErr.ExitCode(),
+	}}
+}
+
+// reportError prints a command failure and returns the exit code of the process.
+// The error is always written to stderr, with a remediation hint for errors returned
+// by the agent, and is also written to stdout for the json and ndjson formats unless
+// the command already printed the error object.
+func reportError(err error) int {
+	report := newErrorReport(err)
+
+	var rpcErr *a2aError
+	printed := errors.As(err, &rpcErr) && rpcErr.printed
+
+	if format, formatErr := getOutputFormat(); formatErr == nil && !printed {
+		switch format {
+		case OutputFormatJSON, OutputFormatNDJSON:
+			if printErr := printFormatted(report); printErr == nil && format == OutputFormatJSON {
+				fmt.Println()
+			}
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	if report.Error.Hint != "" {
+		fmt.Fprintf(os.Stderr, "💡 %s\n", report.Error.Hint)
+	}
+	return report.Error.ExitCode
+}
+
+// handleA2AError turns an error of the A2A client into an a2aError when it carries a
+// JSON-RPC error, so that it is reported with a friendly explanation. Other errors are
+// returned unchanged.
+func handleA2AError(err error, method string) error {
+	if err == nil {
+		return nil
+	}
+
+	var rpcErr *a2aError
+	if errors.As(err, &rpcErr) {
+		return err
+	}
+
+	decoded := jsonRPCErrorFrom(err)
+	if decoded == nil {
+		return err
+	}
+
+	rpcErr = &a2aError{Method: method, Code: decoded.Code, Message: decoded.Message}
+	if decoded.Data != nil {
+		rpcErr.Data = *decoded.Data
+	}
+	return rpcErr
+}
+
+// jsonRPCErrorFrom extracts the JSON-RPC error carried by an error of the A2A client,
+// either reported by the client, as the body of a response with an error status, or
+// as a MethodNotFoundError. The client only keeps the message and code, so the data
+// is recovered from the last error object seen on the wire when it matches. It returns
+// nil for other errors.
+func jsonRPCErrorFrom(err error) *adk.JSONRPCError {
+	errStr := err.Error()
+
+	if match := a2aClientErrorPattern.FindStringSubmatch(errStr); match != nil {
+		code, convErr := strconv.Atoi(match[2])
+		if convErr == nil {
+			if recorded := lastRPCError.lookup(code, match[1]); recorded != nil {
+				return recorded
+			}
+			return &adk.JSONRPCError{Code: code, Message: match[1]}
+		}
+	}
+
+	if decoded := jsonRPCErrorObject(errStr); decoded != nil {
+		return decoded
+	}
+	if match := httpStatusErrorPattern.FindStringSubmatch(errStr); match != nil && match[2] != "" {
+		if decoded := jsonRPCErrorObject(match[2]); decoded != nil {
+			return decoded
+		}
+	}
+
+	if strings.Contains(errStr, jsonRPCErrorKinds[jsonRPCMethodNotFound].Name) {
+		return &adk.JSONRPCError{Code: jsonRPCMethodNotFound, Message: errStr}
+	}
+	return nil
+}
+
+// jsonRPCErrorObject decodes the error of a JSON-RPC response, or returns nil when the
+// text is not a JSON object with an error
+func jsonRPCErrorObject(text string) *adk.JSONRPCError {
+	var jsonErr struct {
+		Error *adk.JSONRPCError `json:"error,omitempty"`
+	}
+	if json.Unmarshal([]byte(strings.TrimSpace(text)), &jsonErr) != nil {
+		return nil
+	}
+	return jsonErr.Error
+}
+
+// lastRPCError holds the last JSON-RPC error object returned to the A2A client
+var lastRPCError rpcErrorRecorder
+
+// rpcErrorRecorder remembers the last JSON-RPC error object returned by the server
+type rpcErrorRecorder struct {
+	mu   sync.Mutex
+	last *adk.JSONRPCError
+}
+
+// record stores the error of a JSON-RPC response body, if it has one
+func (r *rpcErrorRecorder) record(body []byte) {
+	var response struct {
+		Error *adk.JSONRPCError `json:"error"`
+	}
+	if json.Unmarshal(body, &response) != nil || response.Error == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.last = response.Error
+}
+
+// lookup returns the last recorded error if it has the given code and message
+func (r *rpcErrorRecorder) lookup(code int, message string) *adk.JSONRPCError {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.last == nil || r.last.Code != code || r.last.Message != message {
+		return nil
+	}
+	return r.last
+}
+
+// rpcErrorTransport records the JSON-RPC errors in the JSON responses passing through it
+type rpcErrorTransport struct {
+	base     http.RoundTripper
+	recorder *rpcErrorRecorder
+}
+
+func (t *rpcErrorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || !strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	t.recorder.record(body)
+	return resp, nil
+}
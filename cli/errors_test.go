@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	client "github.com/inference-gateway/adk/client"
+	adk "github.com/inference-gateway/adk/types"
+	viper "github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+func TestHandleA2AError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		method   string
+		code     int
+		exitCode int
+		message  string
+	}{
+		{
+			name:     "Client error",
+			err:      errors.New("A2A error: task abc (gone) not found (code: -32001)"),
+			method:   "tasks/get",
+			code:     jsonRPCTaskNotFound,
+			exitCode: 20,
+			message:  "❌ Task not found on the agent (-32001: task abc (gone) not found)",
+		},
+		{
+			name:     "Wrapped client error",
+			err:      fmt.Errorf("request failed: %w", errors.New("A2A error: Unsupported part (code: -32005)")),
+			method:   "message/send",
+			code:     jsonRPCContentTypeNotSupported,
+			exitCode: 24,
+			message:  "❌ The agent does not accept the content type of the message (-32005: Unsupported part)",
+		},
+		{
+			name:     "Method not found",
+			err:      errors.New("A2A error: Method not found (code: -32601)"),
+			method:   "tasks/list",
+			code:     jsonRPCMethodNotFound,
+			exitCode: 12,
+			message:  "❌ Method 'tasks/list' not implemented by the agent (-32601: Method not found)",
+		},
+		{
+			name:     "JSON error object",
+			err:      errors.New(`{"error":{"code":-32603,"message":"boom"}}`),
+			code:     jsonRPCInternalError,
+			exitCode: 14,
+			message:  "❌ The agent failed with an internal error (-32603: boom)",
+		},
+		{
+			name:     "Error status with a JSON-RPC body",
+			err:      errors.New(`unexpected status code: 404, body: {"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"Method not found"}}` + "\n"),
+			method:   "tasks/list",
+			code:     jsonRPCMethodNotFound,
+			exitCode: 12,
+			message:  "❌ Method 'tasks/list' not implemented by the agent (-32601: Method not found)",
+		},
+		{
+			name:     "Error name",
+			err:      errors.New("MethodNotFoundError: -32601"),
+			method:   "tasks/list",
+			code:     jsonRPCMethodNotFound,
+			exitCode: 12,
+		},
+		{
+			name:     "Server defined code",
+			err:      errors.New("A2A error: rate limited (code: -32050)"),
+			code:     -32050,
+			exitCode: exitCodeJSONRPCError,
+			message:  "❌ The agent returned JSON-RPC error -32050 (-32050: rate limited)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rpcErr *a2aError
+			if !errors.As(handleA2AError(tt.err, tt.method), &rpcErr) {
+				t.Fatalf("Expected an a2aError for %v", tt.err)
+			}
+			if rpcErr.Code != tt.code || rpcErr.ExitCode() != tt.exitCode || rpcErr.Method != tt.method {
+				t.Errorf("Unexpected error: %+v (exit code %d)", rpcErr, rpcErr.ExitCode())
+			}
+			if tt.message != "" && rpcErr.Error() != tt.message {
+				t.Errorf("Expected %q, got %q", tt.message, rpcErr.Error())
+			}
+		})
+	}
+
+	for _, plain := range []error{
+		errors.New("unexpected status code: 502"),
+		errors.New("unexpected status code: 502, body: <html>Bad Gateway</html>"),
+		errors.New(`Post "http://agent/a2a?task=-32001": dial tcp: connection refused`),
+		errors.New("failed to decode response: TaskNotFoundError in body"),
+	} {
+		if err := handleA2AError(plain, "tasks/get"); err != plain {
+			t.Errorf("Expected errors without a JSON-RPC error to be returned unchanged, got %v", err)
+		}
+	}
+	if handleA2AError(nil, "tasks/get") != nil {
+		t.Error("Expected nil for a nil error")
+	}
+}
+
+func TestHandleA2AError_RecoversData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32005,"message":"Unsupported part","data":{"accepted":["text/plain"]}}}`))
+	}))
+	defer server.Close()
+
+	a2a := client.NewClientWithLogger(server.URL, zap.NewNop())
+	a2a.SetHTTPClient(&http.Client{Transport: &rpcErrorTransport{base: http.DefaultTransport, recorder: &lastRPCError}})
+
+	text := "hello"
+	_, err := a2a.SendTask(context.Background(), adk.MessageSendParams{Message: adk.Message{MessageID: "m1", Role: adk.RoleUser, Parts: []adk.Part{{Text: &text}}}})
+
+	var rpcErr *a2aError
+	if !errors.As(handleA2AError(err, "message/send"), &rpcErr) {
+		t.Fatalf("Expected an a2aError, got %v", err)
+	}
+	data, _ := json.Marshal(rpcErr.Data)
+	if rpcErr.Code != jsonRPCContentTypeNotSupported || string(data) != `{"accepted":["text/plain"]}` {
+		t.Errorf("Expected the error data to be recovered, got %+v", rpcErr)
+	}
+
+	if recorded := lastRPCError.lookup(jsonRPCContentTypeNotSupported, "Other message"); recorded != nil {
+		t.Errorf("Expected no match for another message, got %+v", recorded)
+	}
+}
+
+func TestReportError(t *testing.T) {
+	defer viper.Set("output", "")
+
+	report := func(format string, err error) (string, int) {
+		viper.Set("output", format)
+
+		oldStdout, oldStderr := os.Stdout, os.Stderr
+		r, w, _ := os.Pipe()
+		os.Stdout, os.Stderr = w, w
+		exitCode := reportError(err)
+		_ = w.Close()
+		os.Stdout, os.Stderr = oldStdout, oldStderr
+
+		var buf bytes.Buffer
+		_, _ = buf.ReadFrom(r)
+		return buf.String(), exitCode
+	}
+
+	rpcErr := &a2aError{Method: "tasks/get", Code: jsonRPCTaskNotFound, Message: "task abc not found", Data: map[string]any{"id": "abc"}}
+
+	output, exitCode := report("json", rpcErr)
+	if exitCode != 20 {
+		t.Errorf("Expected exit code 20, got %d", exitCode)
+	}
+	for _, expected := range []string{`"code": -32001`, `"name": "TaskNotFoundError"`, `"id": "abc"`, `"exit_code": 20`, "Error: ❌ Task not found on the agent", "💡 Check the task ID"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected the output to contain %q, got:\n%s", expected, output)
+		}
+	}
+
+	output, exitCode = report("ndjson", errors.New("connection refused"))
+	if exitCode != exitCodeError || !strings.Contains(output, `{"error":{"message":"connection refused","exit_code":1}}`) {
+		t.Errorf("Unexpected report of a plain error (exit code %d):\n%s", exitCode, output)
+	}
+
+	for _, format := range []string{"yaml", "table"} {
+		output, _ = report(format, rpcErr)
+		if strings.Contains(output, "exit_code") || !strings.Contains(output, "Error: ❌") {
+			t.Errorf("Expected only the human readable error for the %s format, got:\n%s", format, output)
+		}
+	}
+
+	rpcErr.printed = true
+	if output, _ = report("json", rpcErr); strings.Contains(output, `"exit_code"`) {
+		t.Errorf("Expected an error already printed by the command not to be repeated, got:\n%s", output)
+	}
+}
@@ -0,0 +1,468 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	zap "go.uber.org/zap"
+	yaml "gopkg.in/yaml.v3"
+
+	adk "github.com/inference-gateway/adk/types"
+)
+
+var (
+	// errFaultDropped is returned for requests dropped by a fault rule
+	errFaultDropped = errors.New("fault injection: request dropped")
+	// errFaultTruncated ends a stream cut short by a fault rule
+	errFaultTruncated = errors.New("fault injection: stream truncated")
+)
+
+// faultRules is a rule file describing the faults injected into A2A traffic
+type faultRules struct {
+	Seed  uint64       `yaml:"seed"`
+	Rules []*faultRule `yaml:"rules"`
+}
+
+// faultRule injects faults into a share of the requests to the matching methods.
+// Request faults are applied before the request is forwarded, stream faults to
+// the SSE events of the response.
+type faultRule struct {
+	Name    string     `yaml:"name"`
+	Methods []string   `yaml:"methods"`
+	Rate    *faultRate `yaml:"rate"`
+
+	Delay      time.Duration `yaml:"delay"`
+	Jitter     time.Duration `yaml:"jitter"`
+	Drop       bool          `yaml:"drop"`
+	HTTPStatus int           `yaml:"http_status"`
+	Error      *mockRPCError `yaml:"error"`
+
+	TruncateAfter int       `yaml:"truncate_after"`
+	Duplicate     faultRate `yaml:"duplicate"`
+	Reorder       faultRate `yaml:"reorder"`
+	Malformed     faultRate `yaml:"malformed"`
+}
+
+// faultRate is a probability, given either as a fraction (0.25) or a percentage ("25%")
+type faultRate float64
+
+func (r *faultRate) UnmarshalYAML(node *yaml.Node) error {
+	value := strings.TrimSpace(node.Value)
+	percent, isPercent := strings.CutSuffix(value, "%")
+
+	rate, err := strconv.ParseFloat(strings.TrimSpace(percent), 64)
+	if isPercent {
+		rate /= 100
+	}
+	if err != nil || rate < 0 || rate > 1 {
+		return fmt.Errorf("line %d: invalid rate %q, expected a fraction between 0 and 1 or a percentage", node.Line, node.Value)
+	}
+
+	*r = faultRate(rate)
+	return nil
+}
+
+// loadFaultRules reads and validates a fault rule file
+func loadFaultRules(filePath string) (*faultRules, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fault rules: %w", err)
+	}
+
+	rules := &faultRules{}
+	if err := yaml.Unmarshal(data, rules); err != nil {
+		return nil, fmt.Errorf("failed to parse fault rules: %w", err)
+	}
+	if len(rules.Rules) == 0 {
+		return nil, fmt.Errorf("%s defines no rules", filePath)
+	}
+
+	for i, rule := range rules.Rules {
+		if rule == nil {
+			return nil, fmt.Errorf("rule %d is empty", i+1)
+		}
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule %d", i+1)
+		}
+		for _, method := range rule.Methods {
+			if _, err := path.Match(method, ""); err != nil {
+				return nil, fmt.Errorf("%s: invalid method pattern %q", rule.Name, method)
+			}
+		}
+
+		switch {
+		case rule.Delay < 0 || rule.Jitter < 0:
+			return nil, fmt.Errorf("%s: delay and jitter must not be negative", rule.Name)
+		case rule.TruncateAfter < 0:
+			return nil, fmt.Errorf("%s: truncate_after must not be negative", rule.Name)
+		case rule.HTTPStatus != 0 && (rule.HTTPStatus < 400 || rule.HTTPStatus > 599):
+			return nil, fmt.Errorf("%s: http_status must be between 400 and 599", rule.Name)
+		case boolCount(rule.Drop, rule.HTTPStatus != 0, rule.Error != nil) > 1:
+			return nil, fmt.Errorf("%s: drop, http_status and error are mutually exclusive", rule.Name)
+		case !rule.hasFault():
+			return nil, fmt.Errorf("%s: no fault configured", rule.Name)
+		}
+	}
+
+	return rules, nil
+}
+
+// boolCount returns how many of the values are true
+func boolCount(values ...bool) int {
+	count := 0
+	for _, value := range values {
+		if value {
+			count++
+		}
+	}
+	return count
+}
+
+func (r *faultRule) hasFault() bool {
+	return r.Delay > 0 || r.Jitter > 0 || r.Drop || r.HTTPStatus != 0 || r.Error != nil ||
+		r.TruncateAfter > 0 || r.Duplicate > 0 || r.Reorder > 0 || r.Malformed > 0
+}
+
+// matches reports whether the rule applies to a method. Methods are JSON-RPC
+// method names, or "GET /path" for plain HTTP requests, and may use glob patterns.
+func (r *faultRule) matches(method string) bool {
+	if len(r.Methods) == 0 {
+		return true
+	}
+	for _, pattern := range r.Methods {
+		if ok, _ := path.Match(pattern, method); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// faultPlan combines the faults of every rule applied to a single request
+type faultPlan struct {
+	delay         time.Duration
+	drop          bool
+	httpStatus    int
+	rpcError      *mockRPCError
+	truncateAfter int
+	duplicate     float64
+	reorder       float64
+	malformed     float64
+}
+
+// affectsStream reports whether the plan modifies the events of a streamed response
+func (p *faultPlan) affectsStream() bool {
+	return p.truncateAfter > 0 || p.duplicate > 0 || p.reorder > 0 || p.malformed > 0
+}
+
+// faultTransport is an http.RoundTripper that injects the faults of a rule file
+// into the requests it forwards and the SSE streams it returns. Every injected
+// fault is passed to report.
+type faultTransport struct {
+	base   http.RoundTripper
+	rules  []*faultRule
+	report func(req *http.Request, method, fault string)
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// newFaultTransport wraps base with the rules. A zero seed picks a random one.
+func newFaultTransport(base http.RoundTripper, rules *faultRules, report func(req *http.Request, method, fault string)) *faultTransport {
+	seed := rules.Seed
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	return &faultTransport{
+		base:   base,
+		rules:  rules.Rules,
+		report: report,
+		rng:    rand.New(rand.NewPCG(seed, seed)),
+	}
+}
+
+// chance returns true with the given probability
+func (t *faultTransport) chance(rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+	if rate >= 1 {
+		return true
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.rng.Float64() < rate
+}
+
+// jitter returns a random duration up to limit
+func (t *faultTransport) jitter(limit time.Duration) time.Duration {
+	if limit <= 0 {
+		return 0
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return time.Duration(t.rng.Int64N(int64(limit) + 1))
+}
+
+// plan rolls the rules matching a method, returning nil when none applies
+func (t *faultTransport) plan(method string) *faultPlan {
+	var plan *faultPlan
+	for _, rule := range t.rules {
+		rate := 1.0
+		if rule.Rate != nil {
+			rate = float64(*rule.Rate)
+		}
+		if !rule.matches(method) || !t.chance(rate) {
+			continue
+		}
+
+		if plan == nil {
+			plan = &faultPlan{}
+		}
+		plan.delay += rule.Delay + t.jitter(rule.Jitter)
+		if !plan.drop && plan.httpStatus == 0 && plan.rpcError == nil {
+			plan.drop = rule.Drop
+			plan.httpStatus = rule.HTTPStatus
+			plan.rpcError = rule.Error
+		}
+		if rule.TruncateAfter > 0 && (plan.truncateAfter == 0 || rule.TruncateAfter < plan.truncateAfter) {
+			plan.truncateAfter = rule.TruncateAfter
+		}
+		plan.duplicate = max(plan.duplicate, float64(rule.Duplicate))
+		plan.reorder = max(plan.reorder, float64(rule.Reorder))
+		plan.malformed = max(plan.malformed, float64(rule.Malformed))
+	}
+	return plan
+}
+
+func (t *faultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	method, id, err := faultRequestMethod(req)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := t.plan(method)
+	if plan == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	if plan.delay > 0 {
+		t.report(req, method, fmt.Sprintf("delayed by %s", plan.delay.Round(time.Millisecond)))
+		if !sleepContext(req.Context(), plan.delay) {
+			return nil, req.Context().Err()
+		}
+	}
+
+	switch {
+	case plan.drop:
+		t.report(req, method, "dropped the request")
+		return nil, errFaultDropped
+	case plan.httpStatus != 0:
+		t.report(req, method, fmt.Sprintf("returned HTTP %d", plan.httpStatus))
+		return faultResponse(req, plan.httpStatus, "text/plain; charset=utf-8", []byte(http.StatusText(plan.httpStatus)+"\n")), nil
+	case plan.rpcError != nil:
+		t.report(req, method, fmt.Sprintf("returned error %d: %s", plan.rpcError.Code, plan.rpcError.Message))
+		body, err := json.Marshal(adk.JSONRPCErrorResponse{
+			JSONRPC: "2.0",
+			ID:      id,
+			Error:   adk.JSONRPCError{Code: plan.rpcError.Code, Message: plan.rpcError.Message},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode injected error: %w", err)
+		}
+		if method == "message/stream" || method == "tasks/resubscribe" {
+			return faultResponse(req, http.StatusOK, "text/event-stream", fmt.Appendf(nil, "data: %s\n\n", body)), nil
+		}
+		return faultResponse(req, http.StatusOK, "application/json", append(body, '\n')), nil
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || !plan.affectsStream() || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		return resp, err
+	}
+
+	resp.Body = &faultStream{
+		body:      resp.Body,
+		reader:    bufio.NewReader(resp.Body),
+		plan:      plan,
+		transport: t,
+		req:       req,
+		method:    method,
+	}
+	resp.ContentLength = -1
+	return resp, nil
+}
+
+// faultRequestMethod returns the JSON-RPC method and ID of a request, restoring
+// its body. Other requests are identified by their HTTP method and path.
+func faultRequestMethod(req *http.Request) (string, any, error) {
+	if req.Method != http.MethodPost || req.Body == nil || req.Body == http.NoBody {
+		return req.Method + " " + req.URL.Path, nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+
+	var rpcReq jsonRPCRequest
+	if json.Unmarshal(body, &rpcReq) != nil || rpcReq.Method == "" {
+		return req.Method + " " + req.URL.Path, nil, nil
+	}
+	return rpcReq.Method, rpcReq.ID, nil
+}
+
+// faultResponse builds a response answered by the fault transport itself
+func faultResponse(req *http.Request, status int, contentType string, body []byte) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{contentType}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// faultStream applies the stream faults of a plan to the SSE events of a
+// response body as the client reads them
+type faultStream struct {
+	body      io.ReadCloser
+	reader    *bufio.Reader
+	plan      *faultPlan
+	transport *faultTransport
+	req       *http.Request
+	method    string
+
+	out    []byte
+	held   []byte
+	events int
+	err    error
+}
+
+func (s *faultStream) Read(b []byte) (int, error) {
+	for len(s.out) == 0 {
+		if s.err != nil {
+			return 0, s.err
+		}
+		s.next()
+	}
+
+	n := copy(b, s.out)
+	s.out = s.out[n:]
+	return n, nil
+}
+
+func (s *faultStream) Close() error {
+	return s.body.Close()
+}
+
+// next reads the following event from the upstream stream and queues it, or
+// the events it turns into, for the client
+func (s *faultStream) next() {
+	event, err := readSSEEvent(s.reader)
+
+	if bytes.Contains(event, []byte("data:")) {
+		s.events++
+		s.queueEvent(event)
+	} else {
+		s.out = append(s.out, event...)
+	}
+
+	if err != nil && s.err == nil {
+		s.out = append(s.out, s.held...)
+		s.held = nil
+		s.err = err
+	}
+}
+
+// queueEvent applies the stream faults to a single data event
+func (s *faultStream) queueEvent(event []byte) {
+	report := func(fault string) {
+		s.transport.report(s.req, s.method, fault)
+	}
+
+	if s.plan.truncateAfter > 0 && s.events > s.plan.truncateAfter {
+		report(fmt.Sprintf("truncated the stream after %d event(s)", s.plan.truncateAfter))
+		s.out = append(s.out, s.held...)
+		s.held = nil
+		s.err = errFaultTruncated
+		return
+	}
+
+	if s.transport.chance(s.plan.malformed) {
+		report(fmt.Sprintf("malformed event %d", s.events))
+		event = malformSSEEvent(event)
+	}
+
+	if s.held != nil {
+		report(fmt.Sprintf("delivered event %d before event %d", s.events, s.events-1))
+		s.out = append(s.out, event...)
+		s.out = append(s.out, s.held...)
+		s.held = nil
+		return
+	}
+	if s.transport.chance(s.plan.reorder) {
+		s.held = event
+		return
+	}
+
+	s.out = append(s.out, event...)
+	if s.transport.chance(s.plan.duplicate) {
+		report(fmt.Sprintf("duplicated event %d", s.events))
+		s.out = append(s.out, event...)
+	}
+}
+
+// readSSEEvent reads the lines of a single SSE event up to and including the
+// blank line that ends it
+func readSSEEvent(reader *bufio.Reader) ([]byte, error) {
+	var event []byte
+	for {
+		line, err := reader.ReadBytes('\n')
+		event = append(event, line...)
+		if err != nil {
+			return event, err
+		}
+		if len(bytes.TrimRight(line, "\r\n")) == 0 {
+			return event, nil
+		}
+	}
+}
+
+// malformSSEEvent cuts the data of an event in half so it is no longer valid JSON
+func malformSSEEvent(event []byte) []byte {
+	var data []byte
+	for _, line := range bytes.Split(event, []byte("\n")) {
+		if payload, ok := bytes.CutPrefix(bytes.TrimRight(line, "\r"), []byte("data:")); ok {
+			data = append(data, bytes.TrimSpace(payload)...)
+		}
+	}
+	return fmt.Appendf(nil, "data: %s\n\n", data[:len(data)/2])
+}
+
+// logInjectedFault reports a fault injected into the requests of the A2A client
+func logInjectedFault(_ *http.Request, method, fault string) {
+	logger.Warn("Injected fault", zap.String("method", method), zap.String("fault", fault))
+}
@@ -0,0 +1,295 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	client "github.com/inference-gateway/adk/client"
+	adk "github.com/inference-gateway/adk/types"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// roundTripperFunc adapts a function to http.RoundTripper
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// faultReports collects the faults reported by a fault transport under test
+type faultReports struct {
+	mu     sync.Mutex
+	faults []string
+}
+
+func (r *faultReports) report(_ *http.Request, method, fault string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.faults = append(r.faults, method+": "+fault)
+}
+
+func writeFaultRules(t *testing.T, content string) *faultRules {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "faults.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write fault rules: %v", err)
+	}
+	rules, err := loadFaultRules(path)
+	if err != nil {
+		t.Fatalf("loadFaultRules failed: %v", err)
+	}
+	return rules
+}
+
+func TestLoadFaultRules(t *testing.T) {
+	rules := writeFaultRules(t, `
+seed: 7
+rules:
+  - name: slow streams
+    methods: [message/stream, "tasks/*"]
+    rate: 25%
+    delay: 200ms
+    jitter: 50ms
+    duplicate: 0.1
+  - drop: true
+`)
+	if rules.Seed != 7 || len(rules.Rules) != 2 {
+		t.Fatalf("Unexpected rules: %+v", rules)
+	}
+	first := rules.Rules[0]
+	if *first.Rate != 0.25 || first.Delay != 200*time.Millisecond || first.Duplicate != 0.1 {
+		t.Errorf("Unexpected rule: %+v", first)
+	}
+	if !first.matches("tasks/get") || first.matches("message/send") {
+		t.Error("Expected method patterns to match by glob")
+	}
+	if rules.Rules[1].Name != "rule 2" || !rules.Rules[1].matches("GET /health") {
+		t.Errorf("Expected a rule without methods to match everything, got %+v", rules.Rules[1])
+	}
+
+	invalid := map[string]string{
+		"No rules":     `seed: 1`,
+		"No fault":     "rules:\n  - methods: [tasks/get]",
+		"Bad rate":     "rules:\n  - drop: true\n    rate: 150%",
+		"Bad status":   "rules:\n  - http_status: 200",
+		"Exclusive":    "rules:\n  - drop: true\n    http_status: 503",
+		"Bad pattern":  "rules:\n  - drop: true\n    methods: ['tasks/[']",
+		"Negative":     "rules:\n  - truncate_after: -1",
+		"Invalid YAML": "rules: [",
+	}
+	for name, content := range invalid {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "faults.yaml")
+			if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+				t.Fatalf("failed to write fault rules: %v", err)
+			}
+			if _, err := loadFaultRules(path); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestFaultTransport_RequestFaults(t *testing.T) {
+	rules := writeFaultRules(t, `
+rules:
+  - methods: [tasks/get]
+    delay: 30ms
+    error: {code: -32001, message: Task vanished}
+  - methods: [tasks/cancel]
+    http_status: 503
+  - methods: [message/send]
+    drop: true
+  - methods: [tasks/list]
+    rate: 0
+    drop: true
+`)
+	reports := &faultReports{}
+	a2a := client.NewClientWithLogger(newTestUpstream(t, testMockScenario), zap.NewNop())
+	a2a.SetHTTPClient(&http.Client{Transport: newFaultTransport(http.DefaultTransport, rules, reports.report)})
+	ctx := context.Background()
+
+	start := time.Now()
+	_, err := a2a.GetTask(ctx, adk.TaskQueryParams{ID: "t1"})
+	if err == nil || !strings.Contains(err.Error(), "Task vanished") || !strings.Contains(err.Error(), "-32001") {
+		t.Errorf("Expected the injected JSON-RPC error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("Expected the request to be delayed, took %s", elapsed)
+	}
+
+	if _, err := a2a.CancelTask(ctx, adk.TaskIdParams{ID: "t1"}); err == nil || !strings.Contains(err.Error(), "503") {
+		t.Errorf("Expected the injected HTTP status, got %v", err)
+	}
+
+	transport := newFaultTransport(http.DefaultTransport, rules, reports.report)
+	req, _ := http.NewRequest(http.MethodPost, "http://agent/a2a", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"message/send","params":{}}`))
+	if _, err := transport.RoundTrip(req); !errors.Is(err, errFaultDropped) {
+		t.Errorf("Expected the request to be dropped, got %v", err)
+	}
+
+	if _, err := a2a.ListTasks(ctx, adk.TaskListParams{}); err == nil || !strings.Contains(err.Error(), "503") {
+		t.Errorf("Expected a rule with a zero rate to pass the request to the mock, got %v", err)
+	}
+
+	expected := []string{
+		"tasks/get: delayed by 30ms",
+		"tasks/get: returned error -32001: Task vanished",
+		"tasks/cancel: returned HTTP 503",
+		"message/send: dropped the request",
+	}
+	if strings.Join(reports.faults, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected fault reports:\n%s", strings.Join(reports.faults, "\n"))
+	}
+}
+
+func TestFaultTransport_StreamErrorEvent(t *testing.T) {
+	rules := writeFaultRules(t, `
+rules:
+  - methods: [message/stream]
+    error: {code: -32603, message: model overloaded}
+`)
+	a2a := client.NewClientWithLogger(newTestUpstream(t, testMockScenario), zap.NewNop())
+	a2a.SetHTTPClient(&http.Client{Transport: newFaultTransport(http.DefaultTransport, rules, (&faultReports{}).report)})
+
+	text := "weather"
+	events, err := a2a.SendTaskStreaming(context.Background(), adk.MessageSendParams{Message: adk.Message{MessageID: "m1", Role: adk.RoleUser, Parts: []adk.Part{{Text: &text}}}})
+	if err != nil {
+		t.Fatalf("Expected the error to be delivered as a stream event, got %v", err)
+	}
+	var received []adk.JSONRPCSuccessResponse
+	for event := range events {
+		received = append(received, event)
+	}
+	if len(received) != 1 || received[0].Result != nil {
+		t.Errorf("Expected only the error event instead of the mock's events, got %+v", received)
+	}
+}
+
+func TestFaultStream(t *testing.T) {
+	upstream := "data: {\"n\":1}\n\n: keep-alive\n\ndata: {\"n\":2}\n\ndata: {\"n\":3}\n\ndata: {\"n\":4}\n\n"
+
+	tests := []struct {
+		name     string
+		rule     string
+		expected string
+		err      error
+	}{
+		{
+			name:     "Truncate",
+			rule:     "truncate_after: 2",
+			expected: "data: {\"n\":1}\n\n: keep-alive\n\ndata: {\"n\":2}\n\n",
+			err:      errFaultTruncated,
+		},
+		{
+			name:     "Duplicate",
+			rule:     "duplicate: 100%",
+			expected: "data: {\"n\":1}\n\ndata: {\"n\":1}\n\n: keep-alive\n\ndata: {\"n\":2}\n\ndata: {\"n\":2}\n\ndata: {\"n\":3}\n\ndata: {\"n\":3}\n\ndata: {\"n\":4}\n\ndata: {\"n\":4}\n\n",
+		},
+		{
+			name:     "Reorder",
+			rule:     "reorder: 1",
+			expected: ": keep-alive\n\ndata: {\"n\":2}\n\ndata: {\"n\":1}\n\ndata: {\"n\":4}\n\ndata: {\"n\":3}\n\n",
+		},
+		{
+			name:     "Malformed",
+			rule:     "malformed: 100%\n    methods: [message/stream]\n    truncate_after: 1",
+			expected: "data: {\"n\n\n: keep-alive\n\n",
+			err:      errFaultTruncated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := writeFaultRules(t, "rules:\n  - "+tt.rule)
+			base := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				return faultResponse(req, http.StatusOK, "text/event-stream", []byte(upstream)), nil
+			})
+			transport := newFaultTransport(base, rules, (&faultReports{}).report)
+
+			req, _ := http.NewRequest(http.MethodPost, "http://agent/a2a", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"message/stream","params":{}}`))
+			resp, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("RoundTrip failed: %v", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			body, err := io.ReadAll(resp.Body)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Expected error %v, got %v", tt.err, err)
+			}
+			if string(body) != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, string(body))
+			}
+		})
+	}
+}
+
+func TestA2AProxy_Faults(t *testing.T) {
+	rules := writeFaultRules(t, `
+rules:
+  - methods: [message/stream]
+    truncate_after: 2
+`)
+	a2a, collected := newTestProxy(t, newTestUpstream(t, testMockScenario), rules, nil)
+
+	text := "weather"
+	events, err := a2a.SendTaskStreaming(context.Background(), adk.MessageSendParams{Message: adk.Message{MessageID: "m1", Role: adk.RoleUser, Parts: []adk.Part{{Text: &text}}}})
+	if err != nil {
+		t.Fatalf("SendTaskStreaming through the proxy failed: %v", err)
+	}
+	received := 0
+	for range events {
+		received++
+	}
+	if received != 2 {
+		t.Errorf("Expected the stream to be cut after two events, got %d", received)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(collected.ofKind(proxyEntryStreamEnd)) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	faults := collected.ofKind(proxyEntryFault)
+	if len(faults) != 1 || faults[0].Fault != "truncated the stream after 2 event(s)" || faults[0].Method != "message/stream" {
+		t.Errorf("Expected the injected fault to be logged, got %+v", faults)
+	}
+}
+
+func TestInitA2AClient_FaultsDisableRetries(t *testing.T) {
+	originalClient, originalHTTPClient, originalLogger := a2aClient, a2aHTTPClient, logger
+	defer func() {
+		a2aClient, a2aHTTPClient, logger = originalClient, originalHTTPClient, originalLogger
+		viper.Set("faults", "")
+		viper.Set("server-url", "")
+	}()
+	logger = zap.NewNop()
+
+	path := filepath.Join(t.TempDir(), "faults.yaml")
+	if err := os.WriteFile(path, []byte("rules:\n  - methods: [message/send]\n    drop: true\n"), 0o600); err != nil {
+		t.Fatalf("failed to write fault rules: %v", err)
+	}
+	viper.Set("faults", path)
+	viper.Set("server-url", newTestUpstream(t, testMockScenario))
+	initA2AClient()
+
+	start := time.Now()
+	text := "weather"
+	_, err := a2aClient.SendTask(context.Background(), adk.MessageSendParams{Message: adk.Message{MessageID: "m1", Role: adk.RoleUser, Parts: []adk.Part{{Text: &text}}}})
+	if err == nil || !strings.Contains(err.Error(), "after 1 attempts") {
+		t.Errorf("Expected the dropped request to fail without retries, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected no retry delays, took %s", elapsed)
+	}
+}
@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cobra "github.com/spf13/cobra"
+	viper "github.com/spf13/viper"
+	zap "go.uber.org/zap"
+
+	adk "github.com/inference-gateway/adk/types"
+)
+
+// maxHealthPollInterval caps the backoff between health checks in --wait mode
+const maxHealthPollInterval = 5 * time.Second
+
+// healthCheck is the outcome of a single health request
+type healthCheck struct {
+	Status  string
+	Latency time.Duration
+	Err     error
+}
+
+var healthCmd = &cobra.Command{
+	Use:   "health",
+	Short: "Check the health of the A2A server",
+	Long: `Queries the /health endpoint of the A2A server and reports its status and latency.
+
+The command exits with a non-zero status when the server cannot be reached or its
+status does not meet --until (healthy, or degraded to also accept a degraded server).
+With --wait it polls with exponential backoff until the status is met or --max-wait
+elapses, which is useful to block scripts until an agent is ready.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ensureA2AClient()
+
+		wait, _ := cmd.Flags().GetBool("wait")
+		until, _ := cmd.Flags().GetString("until")
+		maxWait, _ := cmd.Flags().GetDuration("max-wait")
+		interval, _ := cmd.Flags().GetDuration("interval")
+
+		if _, ok := healthRank(until); !ok || until == adk.HealthStatusUnhealthy {
+			return fmt.Errorf("invalid --until value %q (use %s or %s)", until, adk.HealthStatusHealthy, adk.HealthStatusDegraded)
+		}
+		if interval <= 0 {
+			return fmt.Errorf("--interval must be positive")
+		}
+
+		serverURL := viper.GetString("server-url")
+		var (
+			check    healthCheck
+			attempts = 1
+			start    = time.Now()
+		)
+
+		if wait {
+			ctx, cancel := context.WithTimeout(context.Background(), maxWait)
+			defer cancel()
+			check, attempts = waitForHealth(ctx, until, interval)
+		} else {
+			check = checkHealth(context.Background())
+		}
+
+		output := map[string]any{
+			"server_url": serverURL,
+			"healthy":    check.Err == nil && check.Status == adk.HealthStatusHealthy,
+		}
+		if check.Err == nil {
+			output["status"] = check.Status
+			output["latency"] = check.Latency.Round(time.Microsecond).String()
+		} else {
+			output["error"] = check.Err.Error()
+		}
+		if wait {
+			output["attempts"] = attempts
+			output["waited"] = time.Since(start).Round(time.Millisecond).String()
+		}
+
+		if err := printFormatted(output); err != nil {
+			return err
+		}
+
+		switch {
+		case wait && !healthSatisfies(check, until):
+			return fmt.Errorf("server did not become %s within %s", until, maxWait)
+		case check.Err != nil:
+			return fmt.Errorf("health check failed: %w", check.Err)
+		case !healthSatisfies(check, until):
+			return fmt.Errorf("server is %s", check.Status)
+		}
+
+		return nil
+	},
+}
+
+// checkHealth performs a single health request and measures its latency
+func checkHealth(ctx context.Context) healthCheck {
+	start := time.Now()
+	resp, err := a2aClient.GetHealth(ctx)
+	latency := time.Since(start)
+	if err != nil {
+		return healthCheck{Latency: latency, Err: err}
+	}
+	return healthCheck{Status: resp.Status, Latency: latency}
+}
+
+// waitForHealth polls the health endpoint with exponential backoff until the status
+// meets until or the context expires. It returns the last check and the number of attempts.
+func waitForHealth(ctx context.Context, until string, interval time.Duration) (healthCheck, int) {
+	attempts := 0
+	for {
+		attempts++
+		check := checkHealth(ctx)
+		if healthSatisfies(check, until) {
+			return check, attempts
+		}
+
+		logger.Debug("Server not ready yet",
+			zap.Int("attempt", attempts),
+			zap.String("status", check.Status),
+			zap.Error(check.Err),
+			zap.Duration("retry_in", interval))
+
+		select {
+		case <-ctx.Done():
+			return check, attempts
+		case <-time.After(interval):
+		}
+
+		interval = min(interval*2, maxHealthPollInterval)
+	}
+}
+
+// healthSatisfies reports whether a health check meets the requested status
+func healthSatisfies(check healthCheck, until string) bool {
+	if check.Err != nil {
+		return false
+	}
+	got, _ := healthRank(check.Status)
+	want, _ := healthRank(until)
+	return got >= want
+}
+
+// healthRank orders health statuses from unhealthy to healthy
+func healthRank(status string) (int, bool) {
+	switch status {
+	case adk.HealthStatusHealthy:
+		return 2, true
+	case adk.HealthStatusDegraded:
+		return 1, true
+	case adk.HealthStatusUnhealthy:
+		return 0, true
+	default:
+		return 0, false
+	}
+}
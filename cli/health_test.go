@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	client "github.com/inference-gateway/adk/client"
+	adk "github.com/inference-gateway/adk/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// healthSequence returns a GetHealth mock that replays the given statuses, where an
+// empty status simulates an unreachable server
+func healthSequence(statuses ...string) (func(ctx context.Context) (*client.HealthResponse, error), *int) {
+	calls := 0
+	return func(ctx context.Context) (*client.HealthResponse, error) {
+		status := statuses[min(calls, len(statuses)-1)]
+		calls++
+		if status == "" {
+			return nil, fmt.Errorf("connection refused")
+		}
+		return &client.HealthResponse{Status: status}, nil
+	}, &calls
+}
+
+func TestHealthSatisfies(t *testing.T) {
+	tests := []struct {
+		name     string
+		check    healthCheck
+		until    string
+		expected bool
+	}{
+		{"Healthy meets healthy", healthCheck{Status: adk.HealthStatusHealthy}, adk.HealthStatusHealthy, true},
+		{"Degraded does not meet healthy", healthCheck{Status: adk.HealthStatusDegraded}, adk.HealthStatusHealthy, false},
+		{"Degraded meets degraded", healthCheck{Status: adk.HealthStatusDegraded}, adk.HealthStatusDegraded, true},
+		{"Healthy meets degraded", healthCheck{Status: adk.HealthStatusHealthy}, adk.HealthStatusDegraded, true},
+		{"Unknown status", healthCheck{Status: "starting"}, adk.HealthStatusDegraded, false},
+		{"Request error", healthCheck{Err: fmt.Errorf("boom")}, adk.HealthStatusDegraded, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := healthSatisfies(tt.check, tt.until); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestWaitForHealth(t *testing.T) {
+	originalClient := a2aClient
+	originalLogger := logger
+	logger = zap.NewNop()
+	defer func() {
+		a2aClient = originalClient
+		logger = originalLogger
+	}()
+
+	t.Run("Becomes healthy", func(t *testing.T) {
+		healthFunc, calls := healthSequence("", adk.HealthStatusUnhealthy, adk.HealthStatusDegraded, adk.HealthStatusHealthy)
+		a2aClient = &mockA2AClient{getHealthFunc: healthFunc}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		check, attempts := waitForHealth(ctx, adk.HealthStatusHealthy, time.Millisecond)
+		if check.Status != adk.HealthStatusHealthy || check.Err != nil {
+			t.Errorf("Expected healthy result, got %+v", check)
+		}
+		if attempts != 4 || *calls != 4 {
+			t.Errorf("Expected 4 attempts, got %d (calls: %d)", attempts, *calls)
+		}
+	})
+
+	t.Run("Gives up after max wait", func(t *testing.T) {
+		healthFunc, _ := healthSequence(adk.HealthStatusDegraded)
+		a2aClient = &mockA2AClient{getHealthFunc: healthFunc}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		check, attempts := waitForHealth(ctx, adk.HealthStatusHealthy, time.Millisecond)
+		if healthSatisfies(check, adk.HealthStatusHealthy) {
+			t.Errorf("Expected the wait to time out, got %+v", check)
+		}
+		if attempts < 2 {
+			t.Errorf("Expected several attempts, got %d", attempts)
+		}
+	})
+}
+
+func TestHealthCmd(t *testing.T) {
+	originalClient := a2aClient
+	originalLogger := logger
+	logger = zap.NewNop()
+	defer func() {
+		a2aClient = originalClient
+		logger = originalLogger
+	}()
+
+	tests := []struct {
+		name         string
+		statuses     []string
+		until        string
+		zeroInterval bool
+		expectError  bool
+	}{
+		{name: "Healthy", statuses: []string{adk.HealthStatusHealthy}, until: adk.HealthStatusHealthy},
+		{name: "Degraded fails healthy", statuses: []string{adk.HealthStatusDegraded}, until: adk.HealthStatusHealthy, expectError: true},
+		{name: "Degraded accepted", statuses: []string{adk.HealthStatusDegraded}, until: adk.HealthStatusDegraded},
+		{name: "Unreachable", statuses: []string{""}, until: adk.HealthStatusHealthy, expectError: true},
+		{name: "Invalid until", statuses: []string{adk.HealthStatusHealthy}, until: "ready", expectError: true},
+		{name: "Zero interval", statuses: []string{adk.HealthStatusHealthy}, until: adk.HealthStatusHealthy, zeroInterval: true, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			healthFunc, _ := healthSequence(tt.statuses...)
+			a2aClient = &mockA2AClient{getHealthFunc: healthFunc}
+			viper.Set("output", "json")
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			cmd := &cobra.Command{}
+			cmd.Flags().Bool("wait", false, "")
+			cmd.Flags().String("until", tt.until, "")
+			cmd.Flags().Duration("max-wait", time.Second, "")
+			interval := time.Millisecond
+			if tt.zeroInterval {
+				interval = 0
+			}
+			cmd.Flags().Duration("interval", interval, "")
+
+			err := healthCmd.RunE(cmd, []string{})
+
+			_ = w.Close()
+			os.Stdout = oldStdout
+			var buf bytes.Buffer
+			_, _ = buf.ReadFrom(r)
+
+			if (err != nil) != tt.expectError {
+				t.Fatalf("Expected error=%v, got: %v", tt.expectError, err)
+			}
+			if tt.until == "ready" || tt.zeroInterval {
+				return
+			}
+
+			var output map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &output); err != nil {
+				t.Fatalf("Output is not valid JSON: %v\n%s", err, buf.String())
+			}
+			if tt.statuses[0] != "" {
+				if output["status"] != tt.statuses[0] {
+					t.Errorf("Expected status %q, got %v", tt.statuses[0], output["status"])
+				}
+				if _, ok := output["latency"]; !ok {
+					t.Errorf("Expected latency in output, got %v", output)
+				}
+			} else if _, ok := output["error"]; !ok {
+				t.Errorf("Expected error in output, got %v", output)
+			}
+		})
+	}
+}
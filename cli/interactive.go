@@ -21,6 +21,9 @@ import (
 // backgroundPollInterval is how often a background task is polled for completion.
 const backgroundPollInterval = 1 * time.Second
 
+// chatRecorder records the streams of the interactive session when --record is set.
+var chatRecorder *streamRecorder
+
 // chatMode selects how messages are exchanged with the A2A server.
 type chatMode int
 
@@ -667,10 +670,17 @@ func (m interactiveModel) View() string {
 
 func startStreamCmd(params adk.MessageSendParams) tea.Cmd {
 	return func() tea.Msg {
+		stream := 0
+		if chatRecorder != nil {
+			stream = chatRecorder.startStream("message/stream", params)
+		}
 		ch, err := a2aClient.SendTaskStreaming(context.Background(), params)
 		if err != nil {
 			return agentErrorMsg{err: handleA2AError(err, "message/stream")}
 		}
+		if chatRecorder != nil {
+			ch = chatRecorder.tee(stream, ch)
+		}
 		return streamStartedMsg{ch: ch}
 	}
 }
@@ -0,0 +1,605 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// jsonPathTemplate is a compiled kubectl-style JSONPath template such as
+// "{.status.state}" or "{range .tasks[*]}{.id}{'\t'}{.status.state}{'\n'}{end}"
+type jsonPathTemplate struct {
+	nodes []jsonPathNode
+}
+
+// jsonPathNode is a literal text, a path expression or a range block of a template
+type jsonPathNode struct {
+	text     string
+	path     []jsonPathStep
+	isRange  bool
+	children []jsonPathNode
+}
+
+// jsonPathStep is a single selector of a path expression
+type jsonPathStep struct {
+	kind   string // field, recursive, wildcard, index, slice or filter
+	field  string
+	index  int
+	start  *int
+	end    *int
+	filter *jsonPathFilter
+}
+
+// jsonPathFilter is a [?(@.path op value)] filter expression
+type jsonPathFilter struct {
+	path  []jsonPathStep
+	op    string
+	value any
+}
+
+// jsonPathToken is a literal text or a {...} action of a template
+type jsonPathToken struct {
+	text     string
+	isAction bool
+}
+
+// parseJSONPath compiles a JSONPath template. A template without braces is
+// treated as a single expression, so ".status.state" equals "{.status.state}".
+func parseJSONPath(template string) (*jsonPathTemplate, error) {
+	if !strings.Contains(template, "{") {
+		template = "{" + template + "}"
+	}
+
+	var tokens []jsonPathToken
+	for len(template) > 0 {
+		open := strings.IndexByte(template, '{')
+		if open < 0 {
+			tokens = append(tokens, jsonPathToken{text: template})
+			break
+		}
+		if open > 0 {
+			tokens = append(tokens, jsonPathToken{text: template[:open]})
+		}
+
+		closeIdx, err := findActionEnd(template, open)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, jsonPathToken{text: strings.TrimSpace(template[open+1 : closeIdx]), isAction: true})
+		template = template[closeIdx+1:]
+	}
+
+	nodes, rest, err := parseJSONPathNodes(tokens)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("jsonpath: {end} without matching {range}")
+	}
+
+	return &jsonPathTemplate{nodes: nodes}, nil
+}
+
+// parseJSONPathNodes parses tokens up to an {end} action and returns the remaining
+// tokens starting at that {end}
+func parseJSONPathNodes(tokens []jsonPathToken) ([]jsonPathNode, []jsonPathToken, error) {
+	var nodes []jsonPathNode
+	for len(tokens) > 0 {
+		token := tokens[0]
+		tokens = tokens[1:]
+
+		switch {
+		case !token.isAction:
+			nodes = append(nodes, jsonPathNode{text: token.text})
+		case token.text == "end":
+			return nodes, append([]jsonPathToken{token}, tokens...), nil
+		case strings.HasPrefix(token.text, "range "):
+			path, err := parseJSONPathExpression(strings.TrimSpace(strings.TrimPrefix(token.text, "range ")))
+			if err != nil {
+				return nil, nil, err
+			}
+			children, rest, err := parseJSONPathNodes(tokens)
+			if err != nil {
+				return nil, nil, err
+			}
+			if len(rest) == 0 {
+				return nil, nil, fmt.Errorf("jsonpath: {range} without matching {end}")
+			}
+			nodes = append(nodes, jsonPathNode{path: path, isRange: true, children: children})
+			tokens = rest[1:]
+		case strings.HasPrefix(token.text, `"`) || strings.HasPrefix(token.text, "'"):
+			text, err := unquoteJSONPathLiteral(token.text)
+			if err != nil {
+				return nil, nil, err
+			}
+			nodes = append(nodes, jsonPathNode{text: text})
+		default:
+			path, err := parseJSONPathExpression(token.text)
+			if err != nil {
+				return nil, nil, err
+			}
+			nodes = append(nodes, jsonPathNode{path: path})
+		}
+	}
+	return nodes, nil, nil
+}
+
+// findActionEnd returns the index of the brace closing the action opened at open,
+// ignoring braces inside quoted literals
+func findActionEnd(template string, open int) (int, error) {
+	var quote byte
+	for i := open + 1; i < len(template); i++ {
+		c := template[i]
+		switch {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote == 0 && c == '}':
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("jsonpath: unclosed action in %q", template[open:])
+}
+
+// unquoteJSONPathLiteral decodes a single or double quoted literal such as "\n"
+func unquoteJSONPathLiteral(literal string) (string, error) {
+	if len(literal) >= 2 && strings.HasPrefix(literal, "'") && strings.HasSuffix(literal, "'") {
+		literal = `"` + strings.ReplaceAll(literal[1:len(literal)-1], `"`, `\"`) + `"`
+	}
+	text, err := strconv.Unquote(literal)
+	if err != nil {
+		return "", fmt.Errorf("jsonpath: invalid literal %s: %w", literal, err)
+	}
+	return text, nil
+}
+
+// parseJSONPathExpression parses a path such as ".tasks[0].status.state"
+func parseJSONPathExpression(expr string) ([]jsonPathStep, error) {
+	expr = strings.TrimPrefix(strings.TrimPrefix(expr, "$"), "@")
+
+	steps := []jsonPathStep{}
+	for len(expr) > 0 {
+		switch {
+		case strings.HasPrefix(expr, ".."):
+			name, rest := splitJSONPathName(expr[2:])
+			if name == "" {
+				return nil, fmt.Errorf("jsonpath: expected a field name after '..'")
+			}
+			steps = append(steps, jsonPathStep{kind: "recursive", field: name})
+			expr = rest
+		case strings.HasPrefix(expr, ".*"):
+			steps = append(steps, jsonPathStep{kind: "wildcard"})
+			expr = expr[2:]
+		case strings.HasPrefix(expr, "."):
+			name, rest := splitJSONPathName(expr[1:])
+			if name != "" {
+				steps = append(steps, jsonPathStep{kind: "field", field: name})
+			}
+			expr = rest
+		case strings.HasPrefix(expr, "["):
+			closeIdx, err := findBracketEnd(expr)
+			if err != nil {
+				return nil, err
+			}
+			step, err := parseJSONPathBracket(strings.TrimSpace(expr[1:closeIdx]))
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, step)
+			expr = expr[closeIdx+1:]
+		default:
+			name, rest := splitJSONPathName(expr)
+			if name == "" {
+				return nil, fmt.Errorf("jsonpath: unexpected %q", expr)
+			}
+			steps = append(steps, jsonPathStep{kind: "field", field: name})
+			expr = rest
+		}
+	}
+
+	return steps, nil
+}
+
+// splitJSONPathName splits a field name off the start of a path
+func splitJSONPathName(expr string) (string, string) {
+	end := strings.IndexAny(expr, ".[")
+	if end < 0 {
+		return expr, ""
+	}
+	return expr[:end], expr[end:]
+}
+
+// findBracketEnd returns the index of the bracket closing the one at the start of expr
+func findBracketEnd(expr string) (int, error) {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("jsonpath: unclosed '[' in %q", expr)
+}
+
+// parseJSONPathBracket parses the content of a [...] selector
+func parseJSONPathBracket(content string) (jsonPathStep, error) {
+	switch {
+	case content == "*":
+		return jsonPathStep{kind: "wildcard"}, nil
+	case strings.HasPrefix(content, "'") || strings.HasPrefix(content, `"`):
+		name, err := unquoteJSONPathLiteral(content)
+		if err != nil {
+			return jsonPathStep{}, err
+		}
+		return jsonPathStep{kind: "field", field: name}, nil
+	case strings.HasPrefix(content, "?(") && strings.HasSuffix(content, ")"):
+		filter, err := parseJSONPathFilter(strings.TrimSpace(content[2 : len(content)-1]))
+		if err != nil {
+			return jsonPathStep{}, err
+		}
+		return jsonPathStep{kind: "filter", filter: filter}, nil
+	case strings.Contains(content, ":"):
+		startText, endText, _ := strings.Cut(content, ":")
+		step := jsonPathStep{kind: "slice"}
+		for _, bound := range []struct {
+			text   string
+			target **int
+		}{{startText, &step.start}, {endText, &step.end}} {
+			if strings.TrimSpace(bound.text) == "" {
+				continue
+			}
+			value, err := strconv.Atoi(strings.TrimSpace(bound.text))
+			if err != nil {
+				return jsonPathStep{}, fmt.Errorf("jsonpath: invalid slice [%s]", content)
+			}
+			*bound.target = &value
+		}
+		return step, nil
+	default:
+		index, err := strconv.Atoi(content)
+		if err != nil {
+			return jsonPathStep{}, fmt.Errorf("jsonpath: invalid index [%s]", content)
+		}
+		return jsonPathStep{kind: "index", index: index}, nil
+	}
+}
+
+// parseJSONPathFilter parses "@.path", "@.path == 'value'" and similar comparisons
+func parseJSONPathFilter(expr string) (*jsonPathFilter, error) {
+	if left, op, right, ok := cutJSONPathOperator(expr); ok {
+		path, err := parseJSONPathExpression(strings.TrimSpace(left))
+		if err != nil {
+			return nil, err
+		}
+
+		right = strings.TrimSpace(right)
+		var value any
+		if strings.HasPrefix(right, "'") || strings.HasPrefix(right, `"`) {
+			value, err = unquoteJSONPathLiteral(right)
+			if err != nil {
+				return nil, err
+			}
+		} else if err := json.Unmarshal([]byte(right), &value); err != nil {
+			return nil, fmt.Errorf("jsonpath: invalid filter value %q", right)
+		}
+
+		return &jsonPathFilter{path: path, op: op, value: value}, nil
+	}
+
+	path, err := parseJSONPathExpression(expr)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonPathFilter{path: path}, nil
+}
+
+// cutJSONPathOperator splits a filter expression around its first comparison
+// operator outside of quoted literals
+func cutJSONPathOperator(expr string) (left, op, right string, ok bool) {
+	var quote byte
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		case c == '\'' || c == '"':
+			quote = c
+			continue
+		}
+		for _, candidate := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+			if strings.HasPrefix(expr[i:], candidate) {
+				return expr[:i], candidate, expr[i+len(candidate):], true
+			}
+		}
+	}
+	return "", "", "", false
+}
+
+// execute renders the template against JSON-compatible data
+func (t *jsonPathTemplate) execute(data any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := executeJSONPathNodes(&buf, t.nodes, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func executeJSONPathNodes(buf *bytes.Buffer, nodes []jsonPathNode, data any) error {
+	for _, node := range nodes {
+		switch {
+		case node.isRange:
+			for _, item := range evalJSONPath(node.path, data) {
+				if err := executeJSONPathNodes(buf, node.children, item); err != nil {
+					return err
+				}
+			}
+		case node.path != nil:
+			for i, value := range evalJSONPath(node.path, data) {
+				if i > 0 {
+					buf.WriteByte(' ')
+				}
+				text, err := jsonPathValueText(value)
+				if err != nil {
+					return err
+				}
+				buf.WriteString(text)
+			}
+		default:
+			buf.WriteString(node.text)
+		}
+	}
+	return nil
+}
+
+// evalJSONPath returns every value selected by the path. Missing keys select nothing.
+func evalJSONPath(steps []jsonPathStep, data any) []any {
+	current := []any{data}
+	for _, step := range steps {
+		var next []any
+		for _, value := range current {
+			next = append(next, applyJSONPathStep(step, value)...)
+		}
+		current = next
+	}
+	return current
+}
+
+func applyJSONPathStep(step jsonPathStep, value any) []any {
+	switch step.kind {
+	case "field":
+		if m, ok := value.(map[string]any); ok {
+			if v, ok := m[step.field]; ok {
+				return []any{v}
+			}
+		}
+	case "recursive":
+		return recursiveJSONPathField(step.field, value)
+	case "wildcard":
+		return jsonPathChildren(value)
+	case "index":
+		if list, ok := value.([]any); ok {
+			index := step.index
+			if index < 0 {
+				index += len(list)
+			}
+			if index >= 0 && index < len(list) {
+				return []any{list[index]}
+			}
+		}
+	case "slice":
+		if list, ok := value.([]any); ok {
+			start, end := 0, len(list)
+			if step.start != nil {
+				start = clampIndex(*step.start, len(list))
+			}
+			if step.end != nil {
+				end = clampIndex(*step.end, len(list))
+			}
+			if start < end {
+				return append([]any(nil), list[start:end]...)
+			}
+		}
+	case "filter":
+		var matches []any
+		for _, child := range jsonPathChildren(value) {
+			if step.filter.matches(child) {
+				matches = append(matches, child)
+			}
+		}
+		return matches
+	}
+	return nil
+}
+
+// jsonPathChildren returns the elements of a list or the values of an object in key order
+func jsonPathChildren(value any) []any {
+	switch v := value.(type) {
+	case []any:
+		return v
+	case map[string]any:
+		children := make([]any, 0, len(v))
+		for _, key := range sortedKeys(v) {
+			children = append(children, v[key])
+		}
+		return children
+	}
+	return nil
+}
+
+func recursiveJSONPathField(field string, value any) []any {
+	var results []any
+	if m, ok := value.(map[string]any); ok {
+		if v, ok := m[field]; ok {
+			results = append(results, v)
+		}
+	}
+	for _, child := range jsonPathChildren(value) {
+		results = append(results, recursiveJSONPathField(field, child)...)
+	}
+	return results
+}
+
+func clampIndex(index, length int) int {
+	if index < 0 {
+		index += length
+	}
+	return max(0, min(index, length))
+}
+
+// matches reports whether an element satisfies the filter
+func (f *jsonPathFilter) matches(value any) bool {
+	results := evalJSONPath(f.path, value)
+	if f.op == "" {
+		return len(results) > 0
+	}
+	for _, result := range results {
+		if compareJSONPathValues(result, f.op, f.value) {
+			return true
+		}
+	}
+	return false
+}
+
+func compareJSONPathValues(left any, op string, right any) bool {
+	if l, ok := jsonPathNumber(left); ok {
+		if r, ok := jsonPathNumber(right); ok {
+			switch op {
+			case "==":
+				return l == r
+			case "!=":
+				return l != r
+			case "<":
+				return l < r
+			case ">":
+				return l > r
+			case "<=":
+				return l <= r
+			case ">=":
+				return l >= r
+			}
+		}
+	}
+
+	l, lok := left.(string)
+	r, rok := right.(string)
+	if lok && rok {
+		switch op {
+		case "==":
+			return l == r
+		case "!=":
+			return l != r
+		case "<":
+			return l < r
+		case ">":
+			return l > r
+		case "<=":
+			return l <= r
+		case ">=":
+			return l >= r
+		}
+	}
+
+	switch op {
+	case "==":
+		return fmt.Sprint(left) == fmt.Sprint(right)
+	case "!=":
+		return fmt.Sprint(left) != fmt.Sprint(right)
+	}
+	return false
+}
+
+func jsonPathNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// jsonPathValueText renders a selected value: strings as-is, other scalars in
+// their JSON form and objects or lists as compact JSON
+func jsonPathValueText(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case map[string]any, []any:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", fmt.Errorf("jsonpath: failed to marshal value: %w", err)
+		}
+		return string(b), nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+// toJSONValue converts data into its generic JSON form (maps, slices and scalars)
+// so templates address fields by their JSON names
+func toJSONValue(data any) (any, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal output: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal output: %w", err)
+	}
+	return value, nil
+}
+
+// nativeNumbers replaces the JSON numbers in a decoded value with integers or
+// floats, so they compare and print as numbers outside of JSONPath
+func nativeNumbers(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case map[string]any:
+		for key, item := range v {
+			v[key] = nativeNumbers(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = nativeNumbers(item)
+		}
+	}
+	return value
+}
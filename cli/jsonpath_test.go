@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJSONPathTemplate(t *testing.T) {
+	data, err := toJSONValue(map[string]any{
+		"context_id": "ctx-1",
+		"total":      3,
+		"tasks": []map[string]any{
+			{"id": "task-1", "status": map[string]any{"state": "TASK_STATE_COMPLETED"}, "artifacts": []map[string]any{{"parts": []map[string]any{{"text": "done"}}}}},
+			{"id": "task-2", "status": map[string]any{"state": "TASK_STATE_WORKING"}},
+			{"id": "task-3", "status": map[string]any{"state": "TASK_STATE_COMPLETED"}, "priority": 7},
+		},
+	})
+	if err != nil {
+		t.Fatalf("toJSONValue failed: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		template    string
+		expected    string
+		expectError bool
+	}{
+		{name: "Field", template: "{.context_id}", expected: "ctx-1"},
+		{name: "Bare expression", template: ".tasks[0].status.state", expected: "TASK_STATE_COMPLETED"},
+		{name: "Number", template: "{.total}", expected: "3"},
+		{name: "Wildcard", template: "{.tasks[*].id}", expected: "task-1 task-2 task-3"},
+		{name: "Negative index", template: "{.tasks[-1].id}", expected: "task-3"},
+		{name: "Slice", template: "{.tasks[0:2].id}", expected: "task-1 task-2"},
+		{name: "Quoted field", template: "{.tasks[1]['status'].state}", expected: "TASK_STATE_WORKING"},
+		{name: "Recursive descent", template: "{..text}", expected: "done"},
+		{name: "Filter by value", template: `{.tasks[?(@.status.state=="TASK_STATE_COMPLETED")].id}`, expected: "task-1 task-3"},
+		{name: "Filter by number", template: "{.tasks[?(@.priority > 5)].id}", expected: "task-3"},
+		{name: "Filter by existence", template: "{.tasks[?(@.artifacts)].id}", expected: "task-1"},
+		{name: "Filter with operator in literal", template: "{.tasks[?(@.status.state != 'a==b')].id}", expected: "task-1 task-2 task-3"},
+		{name: "Filter with comparison in literal", template: `{.tasks[?(@.id == "task<2")].id}`, expected: ""},
+		{name: "Literal text", template: "total={.total}", expected: "total=3"},
+		{name: "Range", template: `{range .tasks[*]}{.id}{"\t"}{.status.state}{"\n"}{end}`, expected: "task-1\tTASK_STATE_COMPLETED\ntask-2\tTASK_STATE_WORKING\ntask-3\tTASK_STATE_COMPLETED\n"},
+		{name: "Object as JSON", template: "{.tasks[1].status}", expected: `{"state":"TASK_STATE_WORKING"}`},
+		{name: "Missing key", template: "{.missing.field}", expected: ""},
+		{name: "Unclosed action", template: "{.tasks", expectError: true},
+		{name: "Range without end", template: "{range .tasks[*]}{.id}", expectError: true},
+		{name: "End without range", template: "{.id}{end}", expectError: true},
+		{name: "Invalid index", template: "{.tasks[x]}", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := parseJSONPath(tt.template)
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("Expected an error for %q", tt.template)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseJSONPath failed: %v", err)
+			}
+
+			output, err := tmpl.execute(data)
+			if err != nil {
+				t.Fatalf("execute failed: %v", err)
+			}
+			if string(output) != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, string(output))
+			}
+		})
+	}
+}
+
+func TestNativeNumbers(t *testing.T) {
+	value, err := toJSONValue(json.RawMessage(`{"code":-32001,"ratio":0.5,"items":[1,2]}`))
+	if err != nil {
+		t.Fatalf("toJSONValue failed: %v", err)
+	}
+	converted := nativeNumbers(value).(map[string]any)
+	if converted["code"] != int64(-32001) || converted["ratio"] != 0.5 || converted["items"].([]any)[1] != int64(2) {
+		t.Errorf("Unexpected conversion: %#v", converted)
+	}
+}
@@ -0,0 +1,702 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"regexp"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	uuid "github.com/google/uuid"
+	cobra "github.com/spf13/cobra"
+	zap "go.uber.org/zap"
+	yaml "gopkg.in/yaml.v3"
+
+	adk "github.com/inference-gateway/adk/types"
+)
+
+// mockInputPlaceholder is replaced with the text of the user message in scripted replies
+const mockInputPlaceholder = "{{input}}"
+
+// maxMockRequestSize caps the size of a JSON-RPC request accepted by the mock server
+const maxMockRequestSize = 10 << 20
+
+// mockScenario scripts the behaviour of the mock A2A server
+type mockScenario struct {
+	AgentCard map[string]any       `yaml:"agent_card"`
+	Health    string               `yaml:"health"`
+	Methods   map[string]mockFault `yaml:"methods"`
+	Responses []*mockResponse      `yaml:"responses"`
+}
+
+// mockFault delays a request and optionally fails it with an HTTP status or a JSON-RPC error
+type mockFault struct {
+	Delay      time.Duration `yaml:"delay"`
+	HTTPStatus int           `yaml:"http_status"`
+	Error      *mockRPCError `yaml:"error"`
+}
+
+// mockRPCError is a JSON-RPC error injected by a scenario
+type mockRPCError struct {
+	Code    int    `yaml:"code"`
+	Message string `yaml:"message"`
+}
+
+// mockResponse is the scripted reply to messages whose text matches Match. A
+// response with Times set is only used that many times.
+type mockResponse struct {
+	Match     string `yaml:"match"`
+	Times     int    `yaml:"times"`
+	mockFault `yaml:",inline"`
+	Steps     []mockStep `yaml:"steps"`
+
+	pattern *regexp.Regexp
+	used    int
+}
+
+// mockStep is a single state transition or artifact emitted while processing a message
+type mockStep struct {
+	Delay    time.Duration `yaml:"delay"`
+	State    string        `yaml:"state"`
+	Text     string        `yaml:"text"`
+	Artifact *mockArtifact `yaml:"artifact"`
+
+	state adk.TaskState
+}
+
+// mockArtifact is an artifact produced by a scripted step
+type mockArtifact struct {
+	Name string         `yaml:"name"`
+	Text string         `yaml:"text"`
+	Data map[string]any `yaml:"data"`
+}
+
+// defaultMockResponse echoes the user message when no scripted response matches
+var defaultMockResponse = mockResponse{
+	Steps: []mockStep{
+		{State: "working", state: adk.TaskStateWorking},
+		{State: "completed", Text: mockInputPlaceholder, state: adk.TaskStateCompleted},
+	},
+}
+
+// loadMockScenario reads a scenario file, or returns the built-in echo scenario when path is empty
+func loadMockScenario(path string) (*mockScenario, error) {
+	scenario := &mockScenario{}
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read scenario: %w", err)
+		}
+		if err := yaml.Unmarshal(data, scenario); err != nil {
+			return nil, fmt.Errorf("failed to parse scenario: %w", err)
+		}
+	}
+
+	if err := scenario.compile(); err != nil {
+		return nil, err
+	}
+	return scenario, nil
+}
+
+// compile validates the scenario and resolves match patterns and task states
+func (s *mockScenario) compile() error {
+	if s.Health == "" {
+		s.Health = adk.HealthStatusHealthy
+	}
+
+	for i, response := range s.Responses {
+		if response.Match != "" {
+			pattern, err := regexp.Compile(response.Match)
+			if err != nil {
+				return fmt.Errorf("responses[%d]: invalid match pattern: %w", i, err)
+			}
+			response.pattern = pattern
+		}
+
+		if len(response.Steps) == 0 && response.Error == nil && response.HTTPStatus == 0 {
+			return fmt.Errorf("responses[%d]: at least one step, an error or an http_status is required", i)
+		}
+
+		for j := range response.Steps {
+			step := &response.Steps[j]
+			switch {
+			case step.State != "" && step.Artifact != nil:
+				return fmt.Errorf("responses[%d].steps[%d]: a step sets either a state or an artifact, not both", i, j)
+			case step.Artifact != nil:
+			case step.State != "":
+				state := parseTaskState(step.State)
+				if !state.Valid() || state == adk.TaskStateUnspecified {
+					return fmt.Errorf("responses[%d].steps[%d]: unknown state %q", i, j, step.State)
+				}
+				step.state = state
+			default:
+				return fmt.Errorf("responses[%d].steps[%d]: a step must set a state or an artifact", i, j)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Mock namespace command
+var mockCmd = &cobra.Command{
+	Use:   "mock",
+	Short: "Local mock A2A server commands",
+	Long:  "Commands for running a scripted A2A agent locally, without network access or external images.",
+}
+
+var mockServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve a scripted mock A2A agent",
+	Long: `Starts a local A2A server that answers from a YAML scenario file.
+
+The server publishes an agent card and a health endpoint and implements message/send,
+message/stream (SSE), tasks/get, tasks/list and tasks/cancel. Scenarios script the
+replies to matching messages as a sequence of state transitions and artifacts, with
+optional delays, HTTP status codes and JSON-RPC errors. Without --scenario every
+message is echoed back.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addr, _ := cmd.Flags().GetString("addr")
+		scenarioPath, _ := cmd.Flags().GetString("scenario")
+
+		scenario, err := loadMockScenario(scenarioPath)
+		if err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		listener, err := net.Listen("tcp", addr)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", addr, err)
+		}
+
+		baseURL := webhookURLForListener(listener.Addr(), "")
+		mock := newMockServer(scenario, os.Stdout)
+
+		server := &http.Server{
+			Handler:           mock,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		serverErr := make(chan error, 1)
+		go func() {
+			serverErr <- server.Serve(listener)
+		}()
+
+		fmt.Printf("🧪 Mock agent %q listening on %s\n", mock.card["name"], baseURL)
+		if scenarioPath != "" {
+			fmt.Printf("📜 Scenario: %s (%d scripted response(s))\n", scenarioPath, len(scenario.Responses))
+		}
+		fmt.Printf("\nPress Ctrl+C to stop.\n\n")
+
+		select {
+		case err := <-serverErr:
+			if !errors.Is(err, http.ErrServerClosed) {
+				return fmt.Errorf("mock server failed: %w", err)
+			}
+		case <-ctx.Done():
+		}
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logger.Warn("Failed to shut down mock server", zap.Error(err))
+		}
+
+		fmt.Printf("\n📋 Served %d task(s)\n", mock.taskCount())
+		return nil
+	},
+}
+
+// mockServer is the HTTP handler of the mock A2A server. Tasks are kept in memory.
+type mockServer struct {
+	scenario *mockScenario
+	card     map[string]any
+	log      io.Writer
+
+	mu    sync.Mutex
+	tasks map[string]*adk.Task
+	order []string
+}
+
+// newMockServer creates a mock server for a scenario
+func newMockServer(scenario *mockScenario, log io.Writer) *mockServer {
+	card := scenario.AgentCard
+	if card == nil {
+		card = map[string]any{
+			"name":               "Mock Agent",
+			"description":        "Scripted A2A agent served by a2a mock serve",
+			"version":            "1.0.0",
+			"protocolVersion":    "0.3.0",
+			"capabilities":       map[string]any{"streaming": true, "pushNotifications": false},
+			"defaultInputModes":  []string{"text/plain"},
+			"defaultOutputModes": []string{"text/plain"},
+			"skills": []map[string]any{
+				{"id": "echo", "name": "Echo", "description": "Replies according to the loaded scenario", "tags": []string{"mock"}},
+			},
+		}
+	}
+	return &mockServer{
+		scenario: scenario,
+		card:     card,
+		log:      log,
+		tasks:    map[string]*adk.Task{},
+	}
+}
+
+// agentCard returns the scenario agent card. Cards without a URL or interfaces
+// advertise the address the request was sent to.
+func (m *mockServer) agentCard(r *http.Request) map[string]any {
+	_, hasURL := m.card["url"]
+	_, hasInterfaces := m.card["supportedInterfaces"]
+	if hasURL || hasInterfaces {
+		return m.card
+	}
+
+	card := make(map[string]any, len(m.card)+1)
+	for key, value := range m.card {
+		card[key] = value
+	}
+	card["url"] = "http://" + r.Host
+	return card
+}
+
+// mockRequest is an incoming JSON-RPC request
+type mockRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      any             `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+func (m *mockServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/.well-known/agent-card.json" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, m.agentCard(r))
+	case r.URL.Path == "/health" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]string{"status": m.scenario.Health})
+	case r.URL.Path == "/a2a" && r.Method == http.MethodPost:
+		m.serveJSONRPC(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// serveJSONRPC dispatches a JSON-RPC request to the scripted method handlers
+func (m *mockServer) serveJSONRPC(w http.ResponseWriter, r *http.Request) {
+	var req mockRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxMockRequestSize)).Decode(&req); err != nil {
+		writeJSONRPCError(w, nil, jsonRPCParseError, "Parse error")
+		return
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		writeJSONRPCError(w, req.ID, jsonRPCInvalidRequest, "Invalid Request")
+		return
+	}
+
+	_, _ = fmt.Fprintf(m.log, "📨 %s %s\n", time.Now().Format("15:04:05"), req.Method)
+
+	if fault, ok := m.scenario.Methods[req.Method]; ok && m.injectFault(w, r, req, fault) {
+		return
+	}
+
+	switch req.Method {
+	case "message/send":
+		m.handleSend(w, r, req, false)
+	case "message/stream":
+		m.handleSend(w, r, req, true)
+	case "tasks/get":
+		m.handleGet(w, req)
+	case "tasks/list":
+		m.handleList(w, req)
+	case "tasks/cancel":
+		m.handleCancel(w, req)
+	default:
+		writeJSONRPCError(w, req.ID, jsonRPCMethodNotFound, "Method not found")
+	}
+}
+
+// injectFault applies the delay of a fault and reports whether it answered the
+// request. Errors for message/stream are sent as an event of the stream.
+func (m *mockServer) injectFault(w http.ResponseWriter, r *http.Request, req mockRequest, fault mockFault) bool {
+	if !sleepContext(r.Context(), fault.Delay) {
+		return true
+	}
+
+	switch {
+	case fault.HTTPStatus != 0:
+		_, _ = fmt.Fprintf(m.log, "   ⚡ injected HTTP %d\n", fault.HTTPStatus)
+		http.Error(w, http.StatusText(fault.HTTPStatus), fault.HTTPStatus)
+		return true
+	case fault.Error != nil:
+		_, _ = fmt.Fprintf(m.log, "   ⚡ injected error %d: %s\n", fault.Error.Code, fault.Error.Message)
+		if req.Method == "message/stream" {
+			newSSEWriter(w, req.ID).sendError(fault.Error.Code, fault.Error.Message)
+			return true
+		}
+		writeJSONRPCError(w, req.ID, fault.Error.Code, fault.Error.Message)
+		return true
+	}
+	return false
+}
+
+// handleSend processes message/send and message/stream by playing back the matching scripted response
+func (m *mockServer) handleSend(w http.ResponseWriter, r *http.Request, req mockRequest, stream bool) {
+	var params adk.MessageSendParams
+	if err := json.Unmarshal(req.Params, &params); err != nil || len(params.Message.Parts) == 0 {
+		writeJSONRPCError(w, req.ID, jsonRPCInvalidParams, "Invalid params: a message with at least one part is required")
+		return
+	}
+
+	input := partsToText(params.Message.Parts)
+	response := m.matchResponse(input)
+	if m.injectFault(w, r, req, response.mockFault) {
+		return
+	}
+
+	task, err := m.startTask(params.Message)
+	if err != nil {
+		writeJSONRPCError(w, req.ID, jsonRPCTaskNotFound, err.Error())
+		return
+	}
+
+	var events *sseWriter
+	if stream {
+		events = newSSEWriter(w, req.ID)
+		events.send(m.snapshot(task.ID))
+	}
+
+	lastState := -1
+	for i, step := range response.Steps {
+		if step.Artifact == nil {
+			lastState = i
+		}
+	}
+
+	for i, step := range response.Steps {
+		if !sleepContext(r.Context(), step.Delay) {
+			return
+		}
+
+		event, canceled := m.applyStep(task.ID, step, input, i == lastState)
+		if events != nil {
+			events.send(event)
+		}
+		if canceled {
+			break
+		}
+	}
+
+	if !stream {
+		writeJSON(w, http.StatusOK, adk.JSONRPCSuccessResponse{JSONRPC: "2.0", ID: req.ID, Result: m.snapshot(task.ID)})
+	}
+}
+
+// matchResponse returns the first scripted response that matches the message text
+func (m *mockServer) matchResponse(input string) mockResponse {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, response := range m.scenario.Responses {
+		if response.Times > 0 && response.used >= response.Times {
+			continue
+		}
+		if response.pattern != nil && !response.pattern.MatchString(input) {
+			continue
+		}
+		response.used++
+		return *response
+	}
+	return defaultMockResponse
+}
+
+// startTask creates a task for a message, or continues the task the message refers to
+func (m *mockServer) startTask(message adk.Message) (*adk.Task, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if message.TaskID != nil && *message.TaskID != "" {
+		task, ok := m.tasks[*message.TaskID]
+		if !ok {
+			return nil, fmt.Errorf("Task not found: %s", *message.TaskID)
+		}
+		message.ContextID = &task.ContextID
+		task.History = append(task.History, message)
+		task.Status = adk.TaskStatus{State: adk.TaskStateSubmitted, Timestamp: mockNow()}
+		return task, nil
+	}
+
+	contextID := uuid.NewString()
+	if message.ContextID != nil && *message.ContextID != "" {
+		contextID = *message.ContextID
+	}
+	message.ContextID = &contextID
+
+	task := &adk.Task{
+		ID:        uuid.NewString(),
+		ContextID: contextID,
+		Status:    adk.TaskStatus{State: adk.TaskStateSubmitted, Timestamp: mockNow()},
+		History:   []adk.Message{message},
+	}
+	message.TaskID = &task.ID
+	task.History[0] = message
+
+	m.tasks[task.ID] = task
+	m.order = append(m.order, task.ID)
+	return task, nil
+}
+
+// applyStep applies a scripted step to a task and returns the stream event it
+// produces. A task canceled while it is being processed ends with a final
+// canceled status update instead.
+func (m *mockServer) applyStep(taskID string, step mockStep, input string, final bool) (any, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	task := m.tasks[taskID]
+	if task.Status.State == adk.TaskStateCancelled {
+		return adk.TaskStatusUpdateEvent{TaskID: task.ID, ContextID: task.ContextID, Final: true, Status: task.Status}, true
+	}
+
+	if step.Artifact != nil {
+		text := strings.ReplaceAll(step.Artifact.Text, mockInputPlaceholder, input)
+		artifact := adk.Artifact{ArtifactID: uuid.NewString()}
+		if step.Artifact.Name != "" {
+			artifact.Name = &step.Artifact.Name
+		}
+		if text != "" {
+			artifact.Parts = append(artifact.Parts, adk.Part{Text: &text})
+		}
+		if step.Artifact.Data != nil {
+			artifact.Parts = append(artifact.Parts, adk.Part{Data: &adk.DataPart{Data: step.Artifact.Data}})
+		}
+		task.Artifacts = append(task.Artifacts, artifact)
+
+		lastChunk := true
+		return adk.TaskArtifactUpdateEvent{TaskID: task.ID, ContextID: task.ContextID, Artifact: artifact, LastChunk: &lastChunk}, false
+	}
+
+	task.Status = adk.TaskStatus{State: step.state, Timestamp: mockNow()}
+	if step.Text != "" {
+		text := strings.ReplaceAll(step.Text, mockInputPlaceholder, input)
+		message := adk.Message{
+			MessageID: uuid.NewString(),
+			Role:      adk.RoleAgent,
+			Parts:     []adk.Part{{Text: &text}},
+			ContextID: &task.ContextID,
+			TaskID:    &task.ID,
+		}
+		task.Status.Message = &message
+		task.History = append(task.History, message)
+	}
+
+	return adk.TaskStatusUpdateEvent{TaskID: task.ID, ContextID: task.ContextID, Final: final, Status: task.Status}, false
+}
+
+// handleGet serves tasks/get
+func (m *mockServer) handleGet(w http.ResponseWriter, req mockRequest) {
+	var params adk.TaskQueryParams
+	if err := json.Unmarshal(req.Params, &params); err != nil || params.ID == "" {
+		writeJSONRPCError(w, req.ID, jsonRPCInvalidParams, "Invalid params: id is required")
+		return
+	}
+
+	task := m.snapshot(params.ID)
+	if task == nil {
+		writeJSONRPCError(w, req.ID, jsonRPCTaskNotFound, "Task not found: "+params.ID)
+		return
+	}
+	if params.HistoryLength != nil && *params.HistoryLength >= 0 && len(task.History) > *params.HistoryLength {
+		task.History = task.History[len(task.History)-*params.HistoryLength:]
+	}
+
+	writeJSON(w, http.StatusOK, adk.JSONRPCSuccessResponse{JSONRPC: "2.0", ID: req.ID, Result: task})
+}
+
+// handleList serves tasks/list, newest tasks first
+func (m *mockServer) handleList(w http.ResponseWriter, req mockRequest) {
+	var params adk.TaskListParams
+	if len(req.Params) > 0 {
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			writeJSONRPCError(w, req.ID, jsonRPCInvalidParams, "Invalid params: "+err.Error())
+			return
+		}
+	}
+	if params.Limit <= 0 {
+		params.Limit = 50
+	}
+
+	m.mu.Lock()
+	var matched []adk.Task
+	for i := len(m.order) - 1; i >= 0; i-- {
+		task := m.tasks[m.order[i]]
+		if params.ContextID != nil && *params.ContextID != "" && task.ContextID != *params.ContextID {
+			continue
+		}
+		if params.State != nil && *params.State != "" && task.Status.State != *params.State {
+			continue
+		}
+		matched = append(matched, copyTask(task))
+	}
+	m.mu.Unlock()
+
+	result := adk.TaskList{Tasks: []adk.Task{}, TotalSize: len(matched), PageSize: params.Limit}
+	if params.Offset < len(matched) {
+		end := min(params.Offset+params.Limit, len(matched))
+		result.Tasks = matched[params.Offset:end]
+	}
+
+	writeJSON(w, http.StatusOK, adk.JSONRPCSuccessResponse{JSONRPC: "2.0", ID: req.ID, Result: result})
+}
+
+// handleCancel serves tasks/cancel
+func (m *mockServer) handleCancel(w http.ResponseWriter, req mockRequest) {
+	var params adk.TaskIdParams
+	if err := json.Unmarshal(req.Params, &params); err != nil || params.ID == "" {
+		writeJSONRPCError(w, req.ID, jsonRPCInvalidParams, "Invalid params: id is required")
+		return
+	}
+
+	m.mu.Lock()
+	task, ok := m.tasks[params.ID]
+	if !ok {
+		m.mu.Unlock()
+		writeJSONRPCError(w, req.ID, jsonRPCTaskNotFound, "Task not found: "+params.ID)
+		return
+	}
+	if isTerminalState(task.Status.State) && task.Status.State != adk.TaskStateInputRequired {
+		m.mu.Unlock()
+		writeJSONRPCError(w, req.ID, jsonRPCTaskNotCancelable, "Task cannot be canceled in state "+string(task.Status.State))
+		return
+	}
+	task.Status = adk.TaskStatus{State: adk.TaskStateCancelled, Timestamp: mockNow()}
+	result := copyTask(task)
+	m.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, adk.JSONRPCSuccessResponse{JSONRPC: "2.0", ID: req.ID, Result: result})
+}
+
+// snapshot returns a copy of a task, or nil if it does not exist
+func (m *mockServer) snapshot(taskID string) *adk.Task {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	task, ok := m.tasks[taskID]
+	if !ok {
+		return nil
+	}
+	snapshot := copyTask(task)
+	return &snapshot
+}
+
+// taskCount returns the number of tasks created so far
+func (m *mockServer) taskCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.order)
+}
+
+// copyTask copies a task so it can be encoded without holding the server lock
+func copyTask(task *adk.Task) adk.Task {
+	snapshot := *task
+	snapshot.History = append([]adk.Message(nil), task.History...)
+	snapshot.Artifacts = append([]adk.Artifact(nil), task.Artifacts...)
+	return snapshot
+}
+
+// mockNow returns the current time as a task status timestamp
+func mockNow() *adk.Timestamp {
+	now := time.Now().UTC()
+	return &now
+}
+
+// sseWriter writes JSON-RPC responses as server-sent events
+type sseWriter struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+	id      any
+}
+
+// newSSEWriter starts an event stream response
+func newSSEWriter(w http.ResponseWriter, id any) *sseWriter {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	flusher, _ := w.(http.Flusher)
+	return &sseWriter{w: w, flusher: flusher, id: id}
+}
+
+// send writes a single result as a data event
+func (s *sseWriter) send(result any) {
+	data, err := json.Marshal(adk.JSONRPCSuccessResponse{JSONRPC: "2.0", ID: s.id, Result: result})
+	if err != nil {
+		logger.Error("Failed to marshal stream event", zap.Error(err))
+		return
+	}
+	_, _ = fmt.Fprintf(s.w, "data: %s\n\n", data)
+	if s.flusher != nil {
+		s.flusher.Flush()
+	}
+}
+
+// sendError writes a JSON-RPC error as a data event
+func (s *sseWriter) sendError(code int, message string) {
+	data, err := json.Marshal(adk.JSONRPCErrorResponse{JSONRPC: "2.0", ID: s.id, Error: adk.JSONRPCError{Code: code, Message: message}})
+	if err != nil {
+		logger.Error("Failed to marshal stream error", zap.Error(err))
+		return
+	}
+	_, _ = fmt.Fprintf(s.w, "data: %s\n\n", data)
+	if s.flusher != nil {
+		s.flusher.Flush()
+	}
+}
+
+// writeJSON writes a JSON response body
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logger.Debug("Failed to write response", zap.Error(err))
+	}
+}
+
+// writeJSONRPCError writes a JSON-RPC error response
+func writeJSONRPCError(w http.ResponseWriter, id any, code int, message string) {
+	writeJSON(w, http.StatusOK, adk.JSONRPCErrorResponse{
+		JSONRPC: "2.0",
+		ID:      id,
+		Error:   adk.JSONRPCError{Code: code, Message: message},
+	})
+}
+
+// sleepContext waits for d and reports whether ctx was still active afterwards
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,266 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	client "github.com/inference-gateway/adk/client"
+	adk "github.com/inference-gateway/adk/types"
+	"go.uber.org/zap"
+)
+
+const testMockScenario = `
+agent_card:
+  name: Weather Agent
+  description: Reports the weather
+  version: 2.0.0
+  protocolVersion: 0.3.0
+  capabilities:
+    streaming: true
+  defaultInputModes: [text/plain]
+  defaultOutputModes: [text/plain]
+  skills:
+    - id: forecast
+      name: Forecast
+      description: Weather forecasts
+      tags: [weather]
+methods:
+  tasks/list:
+    http_status: 503
+responses:
+  - match: "(?i)flaky"
+    times: 1
+    error:
+      code: -32603
+      message: upstream model unavailable
+  - match: "(?i)weather"
+    steps:
+      - state: working
+        text: Looking up the forecast...
+      - artifact:
+          name: forecast
+          text: "Sunny, 24°C"
+      - state: completed
+        delay: 10ms
+        text: "Forecast for: {{input}}"
+  - match: "(?i)book"
+    steps:
+      - state: input-required
+        text: Which date?
+`
+
+func writeMockScenario(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "scenario.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write scenario: %v", err)
+	}
+	return path
+}
+
+func TestLoadMockScenario(t *testing.T) {
+	scenario, err := loadMockScenario(writeMockScenario(t, testMockScenario))
+	if err != nil {
+		t.Fatalf("loadMockScenario failed: %v", err)
+	}
+	if len(scenario.Responses) != 3 || scenario.Health != adk.HealthStatusHealthy {
+		t.Fatalf("Unexpected scenario: %+v", scenario)
+	}
+	if scenario.Responses[1].Steps[2].state != adk.TaskStateCompleted {
+		t.Errorf("Expected the short state to be resolved, got %q", scenario.Responses[1].Steps[2].state)
+	}
+
+	invalid := map[string]string{
+		"Unknown state":        "responses:\n  - steps:\n      - state: sleeping\n",
+		"State and artifact":   "responses:\n  - steps:\n      - state: working\n        artifact: {text: x}\n",
+		"Empty step":           "responses:\n  - steps:\n      - delay: 1s\n",
+		"No steps":             "responses:\n  - match: x\n",
+		"Invalid pattern":      "responses:\n  - match: \"(\"\n    steps:\n      - state: completed\n",
+		"Invalid YAML":         "responses: [",
+		"Invalid delay format": "responses:\n  - steps:\n      - state: completed\n        delay: soon\n",
+	}
+	for name, content := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if _, err := loadMockScenario(writeMockScenario(t, content)); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+
+	if _, err := loadMockScenario(""); err != nil {
+		t.Errorf("Expected the built-in scenario to load, got: %v", err)
+	}
+}
+
+func newTestMockClient(t *testing.T, scenario string) client.A2AClient {
+	t.Helper()
+
+	originalLogger := logger
+	logger = zap.NewNop()
+	t.Cleanup(func() { logger = originalLogger })
+
+	loaded, err := loadMockScenario(writeMockScenario(t, scenario))
+	if err != nil {
+		t.Fatalf("loadMockScenario failed: %v", err)
+	}
+
+	server := httptest.NewServer(newMockServer(loaded, io.Discard))
+	t.Cleanup(server.Close)
+
+	return client.NewClientWithLogger(server.URL, zap.NewNop())
+}
+
+func TestMockServer_SendAndQuery(t *testing.T) {
+	ctx := context.Background()
+	a2a := newTestMockClient(t, testMockScenario)
+
+	card, err := a2a.GetAgentCard(ctx)
+	if err != nil {
+		t.Fatalf("GetAgentCard failed: %v", err)
+	}
+	if card.Name != "Weather Agent" || card.URL == nil || *card.URL == "" {
+		t.Errorf("Expected the scenario card with a filled in URL, got %+v", card)
+	}
+
+	health, err := a2a.GetHealth(ctx)
+	if err != nil || health.Status != adk.HealthStatusHealthy {
+		t.Errorf("Expected a healthy server, got %+v (%v)", health, err)
+	}
+
+	text := "What is the weather in Berlin?"
+	resp, err := a2a.SendTask(ctx, adk.MessageSendParams{Message: adk.Message{MessageID: "m1", Role: adk.RoleUser, Parts: []adk.Part{{Text: &text}}}})
+	if err != nil {
+		t.Fatalf("SendTask failed: %v", err)
+	}
+	task, err := taskFromResult(resp.Result)
+	if err != nil {
+		t.Fatalf("taskFromResult failed: %v", err)
+	}
+	if task.Status.State != adk.TaskStateCompleted || len(task.Artifacts) != 1 {
+		t.Fatalf("Expected a completed task with one artifact, got %+v", task)
+	}
+	if got := partsToText(task.Status.Message.Parts); got != "Forecast for: "+text {
+		t.Errorf("Expected the input placeholder to be replaced, got %q", got)
+	}
+	if len(task.History) != 3 {
+		t.Errorf("Expected the user message and two agent messages in history, got %d", len(task.History))
+	}
+
+	historyLength := 1
+	resp, err = a2a.GetTask(ctx, adk.TaskQueryParams{ID: task.ID, HistoryLength: &historyLength})
+	if err != nil {
+		t.Fatalf("GetTask failed: %v", err)
+	}
+	fetched, _ := taskFromResult(resp.Result)
+	if fetched.ID != task.ID || len(fetched.History) != 1 {
+		t.Errorf("Expected the task with a trimmed history, got %+v", fetched)
+	}
+
+	if _, err := a2a.GetTask(ctx, adk.TaskQueryParams{ID: "missing"}); err == nil || !strings.Contains(err.Error(), "-32001") {
+		t.Errorf("Expected a task not found error, got %v", err)
+	}
+
+	if _, err := a2a.CancelTask(ctx, adk.TaskIdParams{ID: task.ID}); err == nil || !strings.Contains(err.Error(), "-32002") {
+		t.Errorf("Expected a task not cancelable error, got %v", err)
+	}
+
+	if _, err := a2a.ListTasks(ctx, adk.TaskListParams{}); err == nil || !strings.Contains(err.Error(), "503") {
+		t.Errorf("Expected the injected HTTP 503 on tasks/list, got %v", err)
+	}
+}
+
+func TestMockServer_InputRequiredAndCancel(t *testing.T) {
+	ctx := context.Background()
+	a2a := newTestMockClient(t, "responses:\n  - match: book\n    steps:\n      - state: input-required\n        text: Which date?\n")
+
+	text := "book a table"
+	resp, err := a2a.SendTask(ctx, adk.MessageSendParams{Message: adk.Message{MessageID: "m1", Role: adk.RoleUser, Parts: []adk.Part{{Text: &text}}}})
+	if err != nil {
+		t.Fatalf("SendTask failed: %v", err)
+	}
+	task, _ := taskFromResult(resp.Result)
+	if task.Status.State != adk.TaskStateInputRequired {
+		t.Fatalf("Expected input-required, got %s", task.Status.State)
+	}
+
+	followUp := "tomorrow"
+	resp, err = a2a.SendTask(ctx, adk.MessageSendParams{Message: adk.Message{MessageID: "m2", Role: adk.RoleUser, TaskID: &task.ID, Parts: []adk.Part{{Text: &followUp}}}})
+	if err != nil {
+		t.Fatalf("SendTask follow-up failed: %v", err)
+	}
+	continued, _ := taskFromResult(resp.Result)
+	if continued.ID != task.ID || continued.Status.State != adk.TaskStateCompleted || len(continued.History) != 4 {
+		t.Errorf("Expected the follow-up to continue and complete the task, got %+v", continued)
+	}
+
+	resp, err = a2a.SendTask(ctx, adk.MessageSendParams{Message: adk.Message{MessageID: "m3", Role: adk.RoleUser, Parts: []adk.Part{{Text: &text}}}})
+	if err != nil {
+		t.Fatalf("SendTask failed: %v", err)
+	}
+	pending, _ := taskFromResult(resp.Result)
+
+	resp, err = a2a.CancelTask(ctx, adk.TaskIdParams{ID: pending.ID})
+	if err != nil {
+		t.Fatalf("CancelTask failed: %v", err)
+	}
+	canceled, _ := taskFromResult(resp.Result)
+	if canceled.Status.State != adk.TaskStateCancelled {
+		t.Errorf("Expected a canceled task, got %s", canceled.Status.State)
+	}
+
+	contextID := task.ContextID
+	resp, err = a2a.ListTasks(ctx, adk.TaskListParams{ContextID: &contextID})
+	if err != nil {
+		t.Fatalf("ListTasks failed: %v", err)
+	}
+	var list adk.TaskList
+	resultBytes, _ := json.Marshal(resp.Result)
+	if err := json.Unmarshal(resultBytes, &list); err != nil {
+		t.Fatalf("failed to decode task list: %v", err)
+	}
+	if list.TotalSize != 1 || list.Tasks[0].ID != task.ID {
+		t.Errorf("Expected only the first task in its context, got %+v", list)
+	}
+}
+
+func TestMockServer_Streaming(t *testing.T) {
+	ctx := context.Background()
+	a2a := newTestMockClient(t, testMockScenario)
+
+	oldStdout := os.Stdout
+	_, w, _ := os.Pipe()
+	os.Stdout = w
+	defer func() {
+		_ = w.Close()
+		os.Stdout = oldStdout
+	}()
+
+	flaky := "flaky weather"
+	events, err := a2a.SendTaskStreaming(ctx, adk.MessageSendParams{Message: adk.Message{MessageID: "m1", Role: adk.RoleUser, Parts: []adk.Part{{Text: &flaky}}}})
+	if err != nil {
+		t.Fatalf("SendTaskStreaming failed: %v", err)
+	}
+	summary := consumeStream(events, streamOutputNDJSON)
+	if summary.TaskID != "" || summary.TotalEvents != 1 {
+		t.Errorf("Expected only the injected error event on the first flaky request, got %+v", summary)
+	}
+
+	events, err = a2a.SendTaskStreaming(ctx, adk.MessageSendParams{Message: adk.Message{MessageID: "m2", Role: adk.RoleUser, Parts: []adk.Part{{Text: &flaky}}}})
+	if err != nil {
+		t.Fatalf("SendTaskStreaming failed: %v", err)
+	}
+	summary = consumeStream(events, streamOutputNDJSON)
+
+	if summary.FinalStatus != string(adk.TaskStateCompleted) {
+		t.Errorf("Expected the weather response once the error was used up, got %+v", summary)
+	}
+	if summary.TotalEvents != 4 || summary.StatusUpdates != 2 || summary.ArtifactUpdates != 1 {
+		t.Errorf("Expected a task snapshot, two status updates and an artifact update, got %+v", summary)
+	}
+}
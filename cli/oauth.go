@@ -0,0 +1,737 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	cobra "github.com/spf13/cobra"
+	viper "github.com/spf13/viper"
+	zap "go.uber.org/zap"
+
+	adk "github.com/inference-gateway/adk/types"
+)
+
+// OAuth2 flows supported by "auth login"
+const (
+	oauthFlowAuto              = "auto"
+	oauthFlowClientCredentials = "client-credentials"
+	oauthFlowDeviceCode        = "device-code"
+)
+
+// deviceCodeGrantType is the grant type defined by RFC 8628 for device authorization
+const deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+// tokenExpirySkew refreshes cached tokens slightly before they actually expire
+const tokenExpirySkew = 30 * time.Second
+
+// deviceCodeDefaultInterval is the polling interval used when the authorization
+// server does not specify one
+var deviceCodeDefaultInterval = 5 * time.Second
+
+// oauthEndpoints describes where and how to obtain a token for an agent
+type oauthEndpoints struct {
+	SchemeName             string
+	TokenURL               string
+	DeviceAuthorizationURL string
+	Scopes                 []string
+	ClientCredentials      bool
+}
+
+// oauthToken is a successful token endpoint response (RFC 6749 section 5.1)
+type oauthToken struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int    `json:"expires_in,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+}
+
+// oauthError is an error token endpoint response (RFC 6749 section 5.2)
+type oauthError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+func (e *oauthError) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("%s: %s", e.Code, e.Description)
+	}
+	return e.Code
+}
+
+// deviceAuthorization is a device authorization response (RFC 8628 section 3.2)
+type deviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval,omitempty"`
+}
+
+// cachedToken is a token stored in the token cache for a single server
+type cachedToken struct {
+	AccessToken  string    `json:"access_token"`
+	TokenType    string    `json:"token_type,omitempty"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at,omitzero"`
+	TokenURL     string    `json:"token_url"`
+	ClientID     string    `json:"client_id,omitempty"`
+	Scheme       string    `json:"scheme,omitempty"`
+	Flow         string    `json:"flow,omitempty"`
+	Scopes       []string  `json:"scopes,omitempty"`
+}
+
+// expired reports whether the token is expired or about to expire
+func (t cachedToken) expired(now time.Time) bool {
+	return !t.ExpiresAt.IsZero() && now.Add(tokenExpirySkew).After(t.ExpiresAt)
+}
+
+// Auth namespace command
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Authentication commands",
+	Long:  "Commands for logging in to A2A servers that advertise OAuth2 security schemes in their agent card.",
+}
+
+var authLoginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Log in to the A2A server using its advertised OAuth2 security scheme",
+	Long: `Reads the security schemes advertised by the agent card, obtains an access
+token using the OAuth2 client credentials or device authorization flow and
+caches it for the server URL. Cached tokens are attached to every request and
+refreshed automatically when they expire.
+
+With --flow auto (the default) the client credentials flow is used when a
+client secret is provided or the agent card only advertises that flow, and the
+device authorization flow otherwise.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := rejectTableOutput(); err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		ensureA2AClient()
+
+		schemeName, _ := cmd.Flags().GetString("scheme")
+		flow, _ := cmd.Flags().GetString("flow")
+		clientID, _ := cmd.Flags().GetString("client-id")
+		clientSecret, _ := cmd.Flags().GetString("client-secret")
+		scopes, _ := cmd.Flags().GetStringSlice("scopes")
+		tokenURL, _ := cmd.Flags().GetString("token-url")
+		deviceAuthorizationURL, _ := cmd.Flags().GetString("device-authorization-url")
+
+		if clientSecret == "" {
+			clientSecret = os.Getenv("A2A_CLIENT_SECRET")
+		}
+		if clientID == "" {
+			return fmt.Errorf("--client-id is required")
+		}
+
+		httpClient, err := newHTTPClient(viper.GetDuration("timeout"), nil)
+		if err != nil {
+			return err
+		}
+
+		endpoints := oauthEndpoints{SchemeName: schemeName}
+		if tokenURL == "" || (flow != oauthFlowClientCredentials && deviceAuthorizationURL == "") {
+			agentCard, err := a2aClient.GetAgentCard(ctx)
+			if err != nil {
+				return handleA2AError(err, "agent-card")
+			}
+
+			endpoints, err = resolveOAuthEndpoints(ctx, httpClient, agentCard, schemeName)
+			if err != nil {
+				return err
+			}
+		}
+
+		if tokenURL != "" {
+			endpoints.TokenURL = tokenURL
+		}
+		if deviceAuthorizationURL != "" {
+			endpoints.DeviceAuthorizationURL = deviceAuthorizationURL
+		}
+		if len(scopes) > 0 {
+			endpoints.Scopes = scopes
+		}
+
+		if flow == oauthFlowAuto {
+			flow = autoOAuthFlow(endpoints, clientSecret)
+		}
+
+		logger.Debug("Logging in",
+			zap.String("scheme", endpoints.SchemeName),
+			zap.String("flow", flow),
+			zap.String("token_url", endpoints.TokenURL),
+			zap.Strings("scopes", endpoints.Scopes))
+
+		var token *oauthToken
+		switch flow {
+		case oauthFlowClientCredentials:
+			if clientSecret == "" {
+				return fmt.Errorf("the client credentials flow requires --client-secret or A2A_CLIENT_SECRET")
+			}
+			token, err = clientCredentialsToken(ctx, httpClient, endpoints.TokenURL, clientID, clientSecret, endpoints.Scopes)
+		case oauthFlowDeviceCode:
+			token, err = deviceCodeToken(ctx, httpClient, endpoints, clientID, os.Stderr)
+		default:
+			return fmt.Errorf("unsupported flow %q (use %s, %s or %s)", flow, oauthFlowAuto, oauthFlowClientCredentials, oauthFlowDeviceCode)
+		}
+		if err != nil {
+			return fmt.Errorf("login failed: %w", err)
+		}
+
+		serverURL := viper.GetString("server-url")
+		cached := newCachedToken(token, endpoints, clientID, flow, time.Now())
+		if err := storeCachedToken(serverURL, cached); err != nil {
+			return err
+		}
+
+		output := map[string]any{
+			"logged_in":  true,
+			"server_url": serverURL,
+			"scheme":     endpoints.SchemeName,
+			"flow":       flow,
+			"token_type": cached.TokenType,
+			"scopes":     cached.Scopes,
+		}
+		if !cached.ExpiresAt.IsZero() {
+			output["expires_at"] = cached.ExpiresAt.Format(time.RFC3339)
+		}
+
+		return printFormatted(output)
+	},
+}
+
+var authLogoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Remove the cached token for the A2A server",
+	Long:  "Removes the token cached by 'auth login' for the configured server URL.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := rejectTableOutput(); err != nil {
+			return err
+		}
+
+		serverURL := viper.GetString("server-url")
+
+		removed, err := removeCachedToken(serverURL)
+		if err != nil {
+			return err
+		}
+
+		output := map[string]any{
+			"logged_out": removed,
+			"server_url": serverURL,
+		}
+
+		return printFormatted(output)
+	},
+}
+
+var authStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the cached token for the A2A server",
+	Long:  "Displays the metadata of the token cached for the configured server URL without revealing the token itself.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		serverURL := viper.GetString("server-url")
+
+		tokens, err := loadTokenCache()
+		if err != nil {
+			return err
+		}
+
+		token, ok := tokens[tokenCacheKey(serverURL)]
+		if !ok {
+			return printFormatted(map[string]any{
+				"logged_in":  false,
+				"server_url": serverURL,
+			})
+		}
+
+		output := map[string]any{
+			"logged_in":         true,
+			"server_url":        serverURL,
+			"scheme":            token.Scheme,
+			"flow":              token.Flow,
+			"token_url":         token.TokenURL,
+			"scopes":            token.Scopes,
+			"expired":           token.expired(time.Now()),
+			"refresh_available": token.RefreshToken != "",
+		}
+		if !token.ExpiresAt.IsZero() {
+			output["expires_at"] = token.ExpiresAt.Format(time.RFC3339)
+		}
+
+		return printFormatted(output)
+	},
+}
+
+// autoOAuthFlow picks the flow for --flow auto: client credentials when a secret is
+// given or the scheme only advertises that flow, the device code flow otherwise
+func autoOAuthFlow(endpoints oauthEndpoints, clientSecret string) string {
+	if clientSecret != "" || (endpoints.ClientCredentials && endpoints.DeviceAuthorizationURL == "") {
+		return oauthFlowClientCredentials
+	}
+	return oauthFlowDeviceCode
+}
+
+// resolveOAuthEndpoints picks an OAuth2 or OpenID Connect security scheme from the
+// agent card and returns the endpoints and scopes needed to request a token
+func resolveOAuthEndpoints(ctx context.Context, httpClient *http.Client, card *adk.AgentCard, schemeName string) (oauthEndpoints, error) {
+	candidates := []string{schemeName}
+	if schemeName == "" {
+		candidates = oauthSchemeCandidates(card)
+	}
+	if len(candidates) == 0 {
+		return oauthEndpoints{}, fmt.Errorf("the agent card does not advertise an OAuth2 or OpenID Connect security scheme")
+	}
+
+	name := candidates[0]
+	scheme, ok := card.SecuritySchemes[name]
+	if !ok {
+		return oauthEndpoints{}, fmt.Errorf("security scheme %q not found in the agent card", name)
+	}
+
+	endpoints := oauthEndpoints{
+		SchemeName: name,
+		Scopes:     requiredScopes(card, name),
+	}
+
+	switch {
+	case scheme.Oauth2SecurityScheme != nil:
+		flows := scheme.Oauth2SecurityScheme.Flows
+		var flowScopes map[string]string
+		switch {
+		case flows.ClientCredentials != nil:
+			endpoints.TokenURL = flows.ClientCredentials.TokenURL
+			endpoints.ClientCredentials = true
+			flowScopes = flows.ClientCredentials.Scopes
+		case flows.AuthorizationCode != nil:
+			endpoints.TokenURL = flows.AuthorizationCode.TokenURL
+			flowScopes = flows.AuthorizationCode.Scopes
+		case flows.Password != nil:
+			endpoints.TokenURL = flows.Password.TokenURL
+			flowScopes = flows.Password.Scopes
+		default:
+			return oauthEndpoints{}, fmt.Errorf("security scheme %q does not advertise a token URL", name)
+		}
+		if len(endpoints.Scopes) == 0 {
+			endpoints.Scopes = sortedKeys(flowScopes)
+		}
+	case scheme.OpenIDConnectSecurityScheme != nil:
+		discovery, err := fetchOpenIDConfiguration(ctx, httpClient, scheme.OpenIDConnectSecurityScheme.OpenIDConnectURL)
+		if err != nil {
+			return oauthEndpoints{}, err
+		}
+		endpoints.TokenURL = discovery.TokenEndpoint
+		endpoints.DeviceAuthorizationURL = discovery.DeviceAuthorizationEndpoint
+		endpoints.ClientCredentials = true
+	default:
+		return oauthEndpoints{}, fmt.Errorf("security scheme %q is not an OAuth2 or OpenID Connect scheme", name)
+	}
+
+	return endpoints, nil
+}
+
+// oauthSchemeCandidates lists OAuth2 and OpenID Connect scheme names, preferring
+// the ones referenced by the card's security requirements
+func oauthSchemeCandidates(card *adk.AgentCard) []string {
+	isOAuth := func(name string) bool {
+		scheme, ok := card.SecuritySchemes[name]
+		return ok && (scheme.Oauth2SecurityScheme != nil || scheme.OpenIDConnectSecurityScheme != nil)
+	}
+
+	var candidates []string
+	seen := map[string]bool{}
+	for _, requirement := range card.Security {
+		for _, name := range sortedKeys(requirement.Schemes) {
+			if isOAuth(name) && !seen[name] {
+				candidates = append(candidates, name)
+				seen[name] = true
+			}
+		}
+	}
+	for _, name := range sortedKeys(card.SecuritySchemes) {
+		if isOAuth(name) && !seen[name] {
+			candidates = append(candidates, name)
+			seen[name] = true
+		}
+	}
+	return candidates
+}
+
+// requiredScopes returns the scopes the card's security requirements list for a scheme
+func requiredScopes(card *adk.AgentCard, schemeName string) []string {
+	for _, requirement := range card.Security {
+		if scopes, ok := requirement.Schemes[schemeName]; ok && len(scopes.List) > 0 {
+			return scopes.List
+		}
+	}
+	return nil
+}
+
+// openIDConfiguration holds the fields of an OpenID Connect discovery document used by the debugger
+type openIDConfiguration struct {
+	TokenEndpoint               string `json:"token_endpoint"`
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint"`
+}
+
+// fetchOpenIDConfiguration retrieves an OpenID Connect discovery document
+func fetchOpenIDConfiguration(ctx context.Context, httpClient *http.Client, discoveryURL string) (*openIDConfiguration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discovery request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch OpenID configuration: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code for OpenID configuration: %d", resp.StatusCode)
+	}
+
+	var discovery openIDConfiguration
+	if err := json.NewDecoder(resp.Body).Decode(&discovery); err != nil {
+		return nil, fmt.Errorf("failed to decode OpenID configuration: %w", err)
+	}
+	return &discovery, nil
+}
+
+// clientCredentialsToken requests a token using the client credentials grant
+func clientCredentialsToken(ctx context.Context, httpClient *http.Client, tokenURL, clientID, clientSecret string, scopes []string) (*oauthToken, error) {
+	if tokenURL == "" {
+		return nil, fmt.Errorf("no token URL available, use --token-url")
+	}
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(scopes) > 0 {
+		form.Set("scope", strings.Join(scopes, " "))
+	}
+
+	return requestToken(ctx, httpClient, tokenURL, form, clientID, clientSecret)
+}
+
+// deviceCodeToken runs the device authorization grant, printing the user
+// instructions to out and polling the token endpoint until the user approves
+func deviceCodeToken(ctx context.Context, httpClient *http.Client, endpoints oauthEndpoints, clientID string, out io.Writer) (*oauthToken, error) {
+	if endpoints.DeviceAuthorizationURL == "" {
+		return nil, fmt.Errorf("no device authorization URL available, use --device-authorization-url")
+	}
+	if endpoints.TokenURL == "" {
+		return nil, fmt.Errorf("no token URL available, use --token-url")
+	}
+
+	form := url.Values{"client_id": {clientID}}
+	if len(endpoints.Scopes) > 0 {
+		form.Set("scope", strings.Join(endpoints.Scopes, " "))
+	}
+
+	var authorization deviceAuthorization
+	if err := postForm(ctx, httpClient, endpoints.DeviceAuthorizationURL, form, "", "", &authorization); err != nil {
+		return nil, fmt.Errorf("device authorization request failed: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(out, "🔐 To log in, open %s and enter the code: %s\n", authorization.VerificationURI, authorization.UserCode)
+	if authorization.VerificationURIComplete != "" {
+		_, _ = fmt.Fprintf(out, "   Or open %s\n", authorization.VerificationURIComplete)
+	}
+	_, _ = fmt.Fprintf(out, "⏳ Waiting for authorization...\n")
+
+	interval := deviceCodeDefaultInterval
+	if authorization.Interval > 0 {
+		interval = time.Duration(authorization.Interval) * time.Second
+	}
+
+	deadline := time.Now().Add(time.Duration(authorization.ExpiresIn) * time.Second)
+	pollForm := url.Values{
+		"grant_type":  {deviceCodeGrantType},
+		"device_code": {authorization.DeviceCode},
+		"client_id":   {clientID},
+	}
+
+	for {
+		if authorization.ExpiresIn > 0 && time.Now().After(deadline) {
+			return nil, fmt.Errorf("device code expired before authorization completed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		token, err := requestToken(ctx, httpClient, endpoints.TokenURL, pollForm, "", "")
+		if err == nil {
+			return token, nil
+		}
+
+		var oauthErr *oauthError
+		if !errors.As(err, &oauthErr) {
+			return nil, err
+		}
+		switch oauthErr.Code {
+		case "authorization_pending":
+			continue
+		case "slow_down":
+			interval += 5 * time.Second
+			continue
+		default:
+			return nil, err
+		}
+	}
+}
+
+// refreshAccessToken exchanges a refresh token for a new access token
+func refreshAccessToken(ctx context.Context, httpClient *http.Client, token cachedToken) (*oauthToken, error) {
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {token.RefreshToken},
+	}
+	if token.ClientID != "" {
+		form.Set("client_id", token.ClientID)
+	}
+	return requestToken(ctx, httpClient, token.TokenURL, form, "", "")
+}
+
+// requestToken posts a token request and decodes the token response
+func requestToken(ctx context.Context, httpClient *http.Client, tokenURL string, form url.Values, clientID, clientSecret string) (*oauthToken, error) {
+	var token oauthToken
+	if err := postForm(ctx, httpClient, tokenURL, form, clientID, clientSecret, &token); err != nil {
+		return nil, err
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("token response did not contain an access token")
+	}
+	return &token, nil
+}
+
+// postForm posts a form to an OAuth2 endpoint and decodes the JSON response into
+// target. OAuth2 error responses are returned as *oauthError.
+func postForm(ctx context.Context, httpClient *http.Client, endpoint string, form url.Values, clientID, clientSecret string, target any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if clientID != "" {
+		req.SetBasicAuth(url.QueryEscape(clientID), url.QueryEscape(clientSecret))
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request to %s failed: %w", endpoint, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var oauthErr oauthError
+		if json.Unmarshal(body, &oauthErr) == nil && oauthErr.Code != "" {
+			return &oauthErr
+		}
+		return fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, endpoint)
+	}
+
+	if err := json.Unmarshal(body, target); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// newCachedToken converts a token response into its cached form
+func newCachedToken(token *oauthToken, endpoints oauthEndpoints, clientID, flow string, now time.Time) cachedToken {
+	cached := cachedToken{
+		AccessToken:  token.AccessToken,
+		TokenType:    token.TokenType,
+		RefreshToken: token.RefreshToken,
+		TokenURL:     endpoints.TokenURL,
+		ClientID:     clientID,
+		Scheme:       endpoints.SchemeName,
+		Flow:         flow,
+		Scopes:       endpoints.Scopes,
+	}
+	if token.Scope != "" {
+		cached.Scopes = strings.Fields(token.Scope)
+	}
+	if token.ExpiresIn > 0 {
+		cached.ExpiresAt = now.Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	return cached
+}
+
+// cachedAccessToken returns the cached access token for a server, refreshing it
+// when it is expired. It returns an empty string when no token is cached.
+func cachedAccessToken(ctx context.Context, httpClient *http.Client, serverURL string) (string, error) {
+	tokens, err := loadTokenCache()
+	if err != nil {
+		return "", err
+	}
+
+	token, ok := tokens[tokenCacheKey(serverURL)]
+	if !ok {
+		return "", nil
+	}
+
+	if !token.expired(time.Now()) {
+		return token.AccessToken, nil
+	}
+
+	if token.RefreshToken == "" {
+		return "", fmt.Errorf("cached token for %s expired, run 'a2a auth login' again", serverURL)
+	}
+
+	refreshed, err := refreshAccessToken(ctx, httpClient, token)
+	if err != nil {
+		return "", fmt.Errorf("failed to refresh cached token: %w", err)
+	}
+
+	updated := newCachedToken(refreshed, oauthEndpoints{
+		SchemeName: token.Scheme,
+		TokenURL:   token.TokenURL,
+		Scopes:     token.Scopes,
+	}, token.ClientID, token.Flow, time.Now())
+	if updated.RefreshToken == "" {
+		updated.RefreshToken = token.RefreshToken
+	}
+
+	if err := storeCachedToken(serverURL, updated); err != nil {
+		return "", err
+	}
+
+	return updated.AccessToken, nil
+}
+
+// tokenCachePath returns the location of the token cache file
+func tokenCachePath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine config directory: %w", err)
+	}
+	return filepath.Join(configDir, "a2a", "tokens.json"), nil
+}
+
+// tokenCacheKey normalizes a server URL for use as a token cache key
+func tokenCacheKey(serverURL string) string {
+	return strings.TrimRight(serverURL, "/")
+}
+
+// loadTokenCache reads every cached token keyed by server URL
+func loadTokenCache() (map[string]cachedToken, error) {
+	path, err := tokenCachePath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]cachedToken{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token cache: %w", err)
+	}
+
+	tokens := map[string]cachedToken{}
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, fmt.Errorf("failed to parse token cache %s: %w", path, err)
+	}
+	return tokens, nil
+}
+
+// saveTokenCache writes the token cache with permissions restricted to the current user
+func saveTokenCache(tokens map[string]cachedToken) error {
+	path, err := tokenCachePath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create token cache directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal token cache: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write token cache: %w", err)
+	}
+	return nil
+}
+
+// storeCachedToken adds or replaces the cached token for a server
+func storeCachedToken(serverURL string, token cachedToken) error {
+	tokens, err := loadTokenCache()
+	if err != nil {
+		return err
+	}
+	tokens[tokenCacheKey(serverURL)] = token
+	return saveTokenCache(tokens)
+}
+
+// removeCachedToken deletes the cached token for a server and reports whether one existed
+func removeCachedToken(serverURL string) (bool, error) {
+	tokens, err := loadTokenCache()
+	if err != nil {
+		return false, err
+	}
+
+	key := tokenCacheKey(serverURL)
+	if _, ok := tokens[key]; !ok {
+		return false, nil
+	}
+	delete(tokens, key)
+	return true, saveTokenCache(tokens)
+}
+
+// sortedKeys returns the keys of a map in sorted order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// attachCachedToken sets the Authorization header from the token cached by
+// "auth login" for the server, if any. Failures are logged and otherwise ignored
+// so that commands still reach servers that do not require authentication.
+func attachCachedToken(headers http.Header, serverURL string, timeout time.Duration) {
+	httpClient, err := newHTTPClient(timeout, nil)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	token, err := cachedAccessToken(ctx, httpClient, serverURL)
+	if err != nil {
+		logger.Warn("Failed to use cached token", zap.Error(err))
+		return
+	}
+	if token != "" {
+		headers.Set("Authorization", "Bearer "+token)
+	}
+}
@@ -0,0 +1,303 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	adk "github.com/inference-gateway/adk/types"
+)
+
+// useTempTokenCache points the token cache at a temporary config directory
+func useTempTokenCache(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+}
+
+// tokenServer is a stand-in OAuth2 authorization server
+type tokenServer struct {
+	mu            sync.Mutex
+	requests      []map[string]string
+	basicUser     string
+	pendingPolls  int
+	issuedAccess  string
+	issuedRefresh string
+	expiresIn     int
+}
+
+func (s *tokenServer) handler(t *testing.T) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"device_code":      "device-123",
+			"user_code":        "ABCD-EFGH",
+			"verification_uri": "https://login.example.com/device",
+			"expires_in":       60,
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("failed to parse token request: %v", err)
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		form := map[string]string{}
+		for key := range r.PostForm {
+			form[key] = r.PostForm.Get(key)
+		}
+		s.requests = append(s.requests, form)
+		s.basicUser, _, _ = r.BasicAuth()
+
+		if form["grant_type"] == deviceCodeGrantType && s.pendingPolls > 0 {
+			s.pendingPolls--
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"error":"authorization_pending"}`)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  s.issuedAccess,
+			"token_type":    "Bearer",
+			"refresh_token": s.issuedRefresh,
+			"expires_in":    s.expiresIn,
+		})
+	})
+	return mux
+}
+
+func TestResolveOAuthEndpoints(t *testing.T) {
+	discovery := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"token_endpoint":"https://idp.example.com/token","device_authorization_endpoint":"https://idp.example.com/device"}`)
+	}))
+	defer discovery.Close()
+
+	card := &adk.AgentCard{
+		SecuritySchemes: map[string]adk.SecurityScheme{
+			"apiKey": {APIKeySecurityScheme: &adk.APIKeySecurityScheme{Name: "X-API-Key"}},
+			"oauth": {Oauth2SecurityScheme: &adk.OAuth2SecurityScheme{
+				Flows: adk.OAuthFlows{
+					ClientCredentials: &adk.ClientCredentialsOAuthFlow{
+						TokenURL: "https://auth.example.com/token",
+						Scopes:   map[string]string{"tasks.write": "", "tasks.read": ""},
+					},
+				},
+			}},
+			"oidc": {OpenIDConnectSecurityScheme: &adk.OpenIDConnectSecurityScheme{OpenIDConnectURL: discovery.URL}},
+		},
+		Security: []adk.Security{
+			{Schemes: map[string]adk.StringList{"oauth": {List: []string{"tasks.read"}}}},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		scheme      string
+		expected    oauthEndpoints
+		expectError bool
+	}{
+		{
+			name:   "Defaults to the scheme required by the card",
+			scheme: "",
+			expected: oauthEndpoints{
+				SchemeName:        "oauth",
+				TokenURL:          "https://auth.example.com/token",
+				Scopes:            []string{"tasks.read"},
+				ClientCredentials: true,
+			},
+		},
+		{
+			name:   "OpenID Connect discovery",
+			scheme: "oidc",
+			expected: oauthEndpoints{
+				SchemeName:             "oidc",
+				TokenURL:               "https://idp.example.com/token",
+				DeviceAuthorizationURL: "https://idp.example.com/device",
+				ClientCredentials:      true,
+			},
+		},
+		{
+			name:        "Non OAuth scheme",
+			scheme:      "apiKey",
+			expectError: true,
+		},
+		{
+			name:        "Unknown scheme",
+			scheme:      "missing",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoints, err := resolveOAuthEndpoints(context.Background(), http.DefaultClient, card, tt.scheme)
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("Expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(endpoints, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, endpoints)
+			}
+		})
+	}
+}
+
+func TestAutoOAuthFlow(t *testing.T) {
+	tests := []struct {
+		name         string
+		endpoints    oauthEndpoints
+		clientSecret string
+		expected     string
+	}{
+		{"Client credentials only", oauthEndpoints{ClientCredentials: true}, "", oauthFlowClientCredentials},
+		{"Device code available", oauthEndpoints{ClientCredentials: true, DeviceAuthorizationURL: "https://idp.example.com/device"}, "", oauthFlowDeviceCode},
+		{"Client secret given", oauthEndpoints{DeviceAuthorizationURL: "https://idp.example.com/device"}, "secret", oauthFlowClientCredentials},
+		{"Authorization code scheme", oauthEndpoints{}, "", oauthFlowDeviceCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := autoOAuthFlow(tt.endpoints, tt.clientSecret); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestClientCredentialsToken(t *testing.T) {
+	ts := &tokenServer{issuedAccess: "access-1", expiresIn: 3600}
+	server := httptest.NewServer(ts.handler(t))
+	defer server.Close()
+
+	token, err := clientCredentialsToken(context.Background(), server.Client(), server.URL+"/token", "debugger", "s3cret", []string{"tasks.read", "tasks.write"})
+	if err != nil {
+		t.Fatalf("clientCredentialsToken failed: %v", err)
+	}
+
+	if token.AccessToken != "access-1" {
+		t.Errorf("Expected access-1, got %q", token.AccessToken)
+	}
+	if ts.basicUser != "debugger" {
+		t.Errorf("Expected client authentication as debugger, got %q", ts.basicUser)
+	}
+	if got := ts.requests[0]["grant_type"]; got != "client_credentials" {
+		t.Errorf("Expected client_credentials grant, got %q", got)
+	}
+	if got := ts.requests[0]["scope"]; got != "tasks.read tasks.write" {
+		t.Errorf("Expected space separated scopes, got %q", got)
+	}
+}
+
+func TestDeviceCodeToken(t *testing.T) {
+	originalInterval := deviceCodeDefaultInterval
+	deviceCodeDefaultInterval = time.Millisecond
+	defer func() { deviceCodeDefaultInterval = originalInterval }()
+
+	ts := &tokenServer{issuedAccess: "access-device", issuedRefresh: "refresh-device", pendingPolls: 2}
+	server := httptest.NewServer(ts.handler(t))
+	defer server.Close()
+
+	endpoints := oauthEndpoints{
+		TokenURL:               server.URL + "/token",
+		DeviceAuthorizationURL: server.URL + "/device",
+		Scopes:                 []string{"tasks.read"},
+	}
+
+	var out strings.Builder
+	token, err := deviceCodeToken(context.Background(), server.Client(), endpoints, "debugger", &out)
+	if err != nil {
+		t.Fatalf("deviceCodeToken failed: %v", err)
+	}
+
+	if token.AccessToken != "access-device" || token.RefreshToken != "refresh-device" {
+		t.Errorf("Unexpected token: %+v", token)
+	}
+	if len(ts.requests) != 3 {
+		t.Errorf("Expected 3 token polls, got %d", len(ts.requests))
+	}
+	if got := ts.requests[0]["device_code"]; got != "device-123" {
+		t.Errorf("Expected device code to be sent, got %q", got)
+	}
+	if !strings.Contains(out.String(), "ABCD-EFGH") {
+		t.Errorf("Expected user code in instructions, got %q", out.String())
+	}
+}
+
+func TestCachedAccessToken(t *testing.T) {
+	useTempTokenCache(t)
+
+	ts := &tokenServer{issuedAccess: "access-refreshed", expiresIn: 3600}
+	server := httptest.NewServer(ts.handler(t))
+	defer server.Close()
+
+	if err := storeCachedToken("https://agent.example.com/", cachedToken{
+		AccessToken: "access-valid",
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}); err != nil {
+		t.Fatalf("storeCachedToken failed: %v", err)
+	}
+	if err := storeCachedToken("https://expired.example.com", cachedToken{
+		AccessToken:  "access-old",
+		RefreshToken: "refresh-old",
+		ExpiresAt:    time.Now().Add(-time.Minute),
+		TokenURL:     server.URL + "/token",
+		ClientID:     "debugger",
+	}); err != nil {
+		t.Fatalf("storeCachedToken failed: %v", err)
+	}
+
+	ctx := context.Background()
+
+	token, err := cachedAccessToken(ctx, server.Client(), "https://agent.example.com")
+	if err != nil || token != "access-valid" {
+		t.Errorf("Expected cached token access-valid, got %q (err: %v)", token, err)
+	}
+
+	token, err = cachedAccessToken(ctx, server.Client(), "https://unknown.example.com")
+	if err != nil || token != "" {
+		t.Errorf("Expected no token for unknown server, got %q (err: %v)", token, err)
+	}
+
+	token, err = cachedAccessToken(ctx, server.Client(), "https://expired.example.com")
+	if err != nil {
+		t.Fatalf("Expected refresh to succeed: %v", err)
+	}
+	if token != "access-refreshed" {
+		t.Errorf("Expected refreshed token, got %q", token)
+	}
+	if got := ts.requests[0]["refresh_token"]; got != "refresh-old" {
+		t.Errorf("Expected refresh_token grant with refresh-old, got %q", got)
+	}
+
+	tokens, err := loadTokenCache()
+	if err != nil {
+		t.Fatalf("loadTokenCache failed: %v", err)
+	}
+	refreshed := tokens["https://expired.example.com"]
+	if refreshed.AccessToken != "access-refreshed" {
+		t.Errorf("Expected refreshed token to be cached, got %q", refreshed.AccessToken)
+	}
+	if refreshed.RefreshToken != "refresh-old" {
+		t.Errorf("Expected refresh token to be kept when not rotated, got %q", refreshed.RefreshToken)
+	}
+
+	removed, err := removeCachedToken("https://agent.example.com")
+	if err != nil || !removed {
+		t.Errorf("Expected token to be removed (err: %v)", err)
+	}
+}
@@ -0,0 +1,267 @@
+package cli
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	cobra "github.com/spf13/cobra"
+	zap "go.uber.org/zap"
+
+	adk "github.com/inference-gateway/adk/types"
+)
+
+// defaultFileMediaType is used for file parts whose media type cannot be determined
+const defaultFileMediaType = "application/octet-stream"
+
+// Media types checked against the agent's input modes for text and data parts
+const (
+	textPartMediaType = "text/plain"
+	dataPartMediaType = "application/json"
+)
+
+// defaultMaxFileSizeMB limits files and data payloads inlined into a message
+const defaultMaxFileSizeMB = 10
+
+// filePartFromPath reads a local file into a file part with base64-encoded bytes.
+// The media type is derived from the file extension, or sniffed from the content,
+// when mediaType is empty. Files larger than maxSize bytes are rejected.
+func filePartFromPath(filePath, mediaType string, maxSize int64) (adk.Part, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return adk.Part{}, fmt.Errorf("failed to read file: %w", err)
+	}
+	if info.IsDir() {
+		return adk.Part{}, fmt.Errorf("%s is a directory", filePath)
+	}
+	if maxSize > 0 && info.Size() > maxSize {
+		return adk.Part{}, fmt.Errorf("%s is %d bytes, larger than the limit of %d bytes", filePath, info.Size(), maxSize)
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return adk.Part{}, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	if mediaType == "" {
+		mediaType = fileMediaType(filepath.Base(filePath), "")
+		if mediaType == defaultFileMediaType && len(content) > 0 {
+			mediaType = http.DetectContentType(content)
+		}
+	}
+
+	name := filepath.Base(filePath)
+	encoded := base64.StdEncoding.EncodeToString(content)
+	return adk.Part{
+		File: &adk.FilePart{
+			FileWithBytes: &encoded,
+			MediaType:     mediaType,
+			Name:          name,
+		},
+	}, nil
+}
+
+// filePartFromURI builds a file part that references the file by URI
+func filePartFromURI(uri, mediaType string) (adk.Part, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Scheme == "" {
+		return adk.Part{}, fmt.Errorf("invalid file URI %q", uri)
+	}
+
+	name := path.Base(parsed.Path)
+	if name == "." || name == "/" {
+		name = ""
+	}
+	return adk.Part{
+		File: &adk.FilePart{
+			FileWithURI: &uri,
+			MediaType:   fileMediaType(name, mediaType),
+			Name:        name,
+		},
+	}, nil
+}
+
+// dataPartFrom builds a structured data part
+func dataPartFrom(data map[string]any) adk.Part {
+	return adk.Part{Data: &adk.DataPart{Data: data}}
+}
+
+// dataPartFromFlag parses a --data value, either inline JSON or @file, into a data
+// part. The payload must be a JSON object of at most maxSize bytes.
+func dataPartFromFlag(value string, maxSize int64) (adk.Part, error) {
+	payload := []byte(value)
+	source := "--data"
+
+	if filePath, ok := strings.CutPrefix(value, "@"); ok {
+		info, err := os.Stat(filePath)
+		if err != nil {
+			return adk.Part{}, fmt.Errorf("failed to read data file: %w", err)
+		}
+		if maxSize > 0 && info.Size() > maxSize {
+			return adk.Part{}, fmt.Errorf("%s is %d bytes, larger than the limit of %d bytes", filePath, info.Size(), maxSize)
+		}
+		if payload, err = os.ReadFile(filePath); err != nil {
+			return adk.Part{}, fmt.Errorf("failed to read data file: %w", err)
+		}
+		source = filePath
+	} else if maxSize > 0 && int64(len(payload)) > maxSize {
+		return adk.Part{}, fmt.Errorf("--data is %d bytes, larger than the limit of %d bytes", len(payload), maxSize)
+	}
+
+	var data map[string]any
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return adk.Part{}, fmt.Errorf("%s must be a JSON object: %w", source, err)
+	}
+	return dataPartFrom(data), nil
+}
+
+// fileMediaType returns mediaType, or the media type registered for the extension of name
+func fileMediaType(name, mediaType string) string {
+	if mediaType != "" {
+		return mediaType
+	}
+	if byExtension := mime.TypeByExtension(filepath.Ext(name)); byExtension != "" {
+		return byExtension
+	}
+	return defaultFileMediaType
+}
+
+// messagePartsFromFlags builds the parts given with --file, --file-uri and --data, in that order
+func messagePartsFromFlags(cmd *cobra.Command) ([]adk.Part, error) {
+	files, _ := cmd.Flags().GetStringArray("file")
+	fileURIs, _ := cmd.Flags().GetStringArray("file-uri")
+	data, _ := cmd.Flags().GetStringArray("data")
+	maxSizeMB, _ := cmd.Flags().GetInt64("max-file-size")
+	if cmd.Flags().Changed("max-file-size") && maxSizeMB < 1 {
+		return nil, fmt.Errorf("--max-file-size must be at least 1")
+	}
+
+	maxSize := maxSizeMB << 20
+	var parts []adk.Part
+
+	for _, filePath := range files {
+		part, err := filePartFromPath(filePath, "", maxSize)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+	}
+
+	for _, uri := range fileURIs {
+		part, err := filePartFromURI(uri, "")
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+	}
+
+	for _, value := range data {
+		part, err := dataPartFromFlag(value, maxSize)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+	}
+
+	return parts, nil
+}
+
+// partMediaType returns the media type a part is checked against the agent's input modes with
+func partMediaType(part adk.Part) string {
+	switch {
+	case part.File != nil:
+		return part.File.MediaType
+	case part.Data != nil:
+		return dataPartMediaType
+	default:
+		return textPartMediaType
+	}
+}
+
+// acceptedInputModes returns the agent's default input modes followed by the input
+// modes of its skills, without duplicates
+func acceptedInputModes(card *adk.AgentCard) []string {
+	var modes []string
+	seen := map[string]bool{}
+	add := func(list []string) {
+		for _, mode := range list {
+			if !seen[mode] {
+				seen[mode] = true
+				modes = append(modes, mode)
+			}
+		}
+	}
+
+	add(card.DefaultInputModes)
+	for _, skill := range card.Skills {
+		add(skill.InputModes)
+	}
+	return modes
+}
+
+// unsupportedInputModes returns the media types of parts that neither the agent's
+// default input modes nor the input modes of one of its skills accept. Cards that
+// declare no input modes accept everything.
+func unsupportedInputModes(card *adk.AgentCard, parts []adk.Part) []string {
+	modes := acceptedInputModes(card)
+	if len(modes) == 0 {
+		return nil
+	}
+
+	var unsupported []string
+	seen := map[string]bool{}
+	for _, part := range parts {
+		mediaType := partMediaType(part)
+		if seen[mediaType] || mediaTypeAccepted(mediaType, modes) {
+			continue
+		}
+		seen[mediaType] = true
+		unsupported = append(unsupported, mediaType)
+	}
+	return unsupported
+}
+
+// mediaTypeAccepted reports whether a media type matches one of the modes, which
+// may use wildcards such as image/* or */*
+func mediaTypeAccepted(mediaType string, modes []string) bool {
+	base, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		base = strings.ToLower(mediaType)
+	}
+	mainType, _, _ := strings.Cut(base, "/")
+
+	for _, mode := range modes {
+		accepted, _, err := mime.ParseMediaType(mode)
+		if err != nil {
+			continue
+		}
+		if accepted == base || accepted == "*/*" || accepted == mainType+"/*" {
+			return true
+		}
+	}
+	return false
+}
+
+// checkInputModes verifies that the agent accepts the media types of the message
+// parts. The check is skipped with a debug log when the agent card cannot be fetched.
+func checkInputModes(ctx context.Context, parts []adk.Part) error {
+	card, err := a2aClient.GetAgentCard(ctx)
+	if err != nil {
+		logger.Debug("skipping input mode check, agent card unavailable", zap.Error(err))
+		return nil
+	}
+
+	if unsupported := unsupportedInputModes(card, parts); len(unsupported) > 0 {
+		return fmt.Errorf("the agent does not accept %s (input modes: %s), use --skip-input-mode-check to send anyway",
+			strings.Join(unsupported, ", "), strings.Join(acceptedInputModes(card), ", "))
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	adk "github.com/inference-gateway/adk/types"
+	"github.com/spf13/cobra"
+)
+
+func TestFilePartFromPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.pdf")
+	if err := os.WriteFile(path, []byte("%PDF-1.7"), 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	part, err := filePartFromPath(path, "", 0)
+	if err != nil {
+		t.Fatalf("filePartFromPath failed: %v", err)
+	}
+	if part.File == nil || part.File.Name != "report.pdf" || part.File.MediaType != "application/pdf" {
+		t.Fatalf("Unexpected file part: %+v", part.File)
+	}
+	if decoded, _ := base64.StdEncoding.DecodeString(*part.File.FileWithBytes); string(decoded) != "%PDF-1.7" {
+		t.Errorf("Expected the file content to be base64 encoded, got %q", *part.File.FileWithBytes)
+	}
+
+	part, _ = filePartFromPath(path, "application/x-custom", 0)
+	if part.File.MediaType != "application/x-custom" {
+		t.Errorf("Expected the explicit media type to win, got %q", part.File.MediaType)
+	}
+
+	if _, err := filePartFromPath(filepath.Join(t.TempDir(), "missing.txt"), "", 0); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+func TestFilePartFromURI(t *testing.T) {
+	part, err := filePartFromURI("https://example.com/files/data.bin", "")
+	if err != nil {
+		t.Fatalf("filePartFromURI failed: %v", err)
+	}
+	if *part.File.FileWithURI != "https://example.com/files/data.bin" || part.File.Name != "data.bin" || part.File.MediaType != defaultFileMediaType {
+		t.Errorf("Unexpected file part: %+v", part.File)
+	}
+
+	if _, err := filePartFromURI("not a uri", ""); err == nil {
+		t.Error("Expected an error for a URI without a scheme")
+	}
+}
+
+func TestFilePartFromPath_SniffingAndLimit(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "upload")
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	if err := os.WriteFile(path, png, 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	part, err := filePartFromPath(path, "", 0)
+	if err != nil {
+		t.Fatalf("filePartFromPath failed: %v", err)
+	}
+	if part.File.MediaType != "image/png" {
+		t.Errorf("Expected the media type to be sniffed from the content, got %q", part.File.MediaType)
+	}
+
+	if _, err := filePartFromPath(path, "", 4); err == nil || !strings.Contains(err.Error(), "larger than the limit") {
+		t.Errorf("Expected the size limit to be enforced, got %v", err)
+	}
+	if _, err := filePartFromPath(dir, "", 0); err == nil {
+		t.Error("Expected an error for a directory")
+	}
+}
+
+func TestMessagePartsFromFlags_MaxFileSize(t *testing.T) {
+	for _, value := range []string{"0", "-1"} {
+		cmd := &cobra.Command{}
+		cmd.Flags().StringArray("file", nil, "")
+		cmd.Flags().StringArray("file-uri", nil, "")
+		cmd.Flags().StringArray("data", []string{`{"a":1}`}, "")
+		cmd.Flags().Int64("max-file-size", defaultMaxFileSizeMB, "")
+		_ = cmd.Flags().Set("max-file-size", value)
+
+		if _, err := messagePartsFromFlags(cmd); err == nil || !strings.Contains(err.Error(), "--max-file-size") {
+			t.Errorf("Expected --max-file-size %s to be rejected, got %v", value, err)
+		}
+	}
+}
+
+func TestDataPartFromFlag(t *testing.T) {
+	part, err := dataPartFromFlag(`{"city":"Berlin","days":3}`, 0)
+	if err != nil {
+		t.Fatalf("dataPartFromFlag failed: %v", err)
+	}
+	if part.Data == nil || part.Data.Data["city"] != "Berlin" {
+		t.Errorf("Unexpected data part: %+v", part.Data)
+	}
+
+	path := filepath.Join(t.TempDir(), "payload.json")
+	if err := os.WriteFile(path, []byte(`{"items":[1,2,3]}`), 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if part, err = dataPartFromFlag("@"+path, 0); err != nil || part.Data.Data["items"] == nil {
+		t.Errorf("Expected the payload to be read from the file, got %+v (%v)", part.Data, err)
+	}
+
+	invalid := map[string]string{
+		"Not JSON":     `city=Berlin`,
+		"Not object":   `[1,2,3]`,
+		"Missing file": "@" + filepath.Join(t.TempDir(), "missing.json"),
+		"Too large":    `{"padding":"` + strings.Repeat("x", 64) + `"}`,
+	}
+	for name, value := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if _, err := dataPartFromFlag(value, 32); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestUnsupportedInputModes(t *testing.T) {
+	pdf, _ := filePartFromURI("https://example.com/report.pdf", "")
+	png, _ := filePartFromURI("https://example.com/chart.png", "")
+	parts := []adk.Part{pdf, png, dataPartFrom(map[string]any{"a": 1})}
+
+	card := &adk.AgentCard{DefaultInputModes: []string{"text/plain", "image/*"}}
+	unsupported := unsupportedInputModes(card, parts)
+	if strings.Join(unsupported, ",") != "application/pdf,application/json" {
+		t.Errorf("Unexpected unsupported media types: %v", unsupported)
+	}
+
+	card.Skills = []adk.AgentSkill{{ID: "reports", InputModes: []string{"application/pdf", "application/json"}}}
+	if unsupported := unsupportedInputModes(card, parts); len(unsupported) != 0 {
+		t.Errorf("Expected skill input modes to be accepted, got %v", unsupported)
+	}
+
+	if unsupported := unsupportedInputModes(&adk.AgentCard{}, parts); len(unsupported) != 0 {
+		t.Errorf("Expected a card without input modes to accept everything, got %v", unsupported)
+	}
+}
+
+func TestCheckInputModes(t *testing.T) {
+	originalClient := a2aClient
+	defer func() { a2aClient = originalClient }()
+
+	a2aClient = &mockA2AClient{
+		getAgentCardFunc: func(ctx context.Context) (*adk.AgentCard, error) {
+			return &adk.AgentCard{
+				DefaultInputModes: []string{"text/plain"},
+				Skills: []adk.AgentSkill{
+					{ID: "charts", InputModes: []string{"text/plain", "image/*"}},
+					{ID: "reports", InputModes: []string{"application/pdf"}},
+				},
+			}, nil
+		},
+	}
+
+	audio, _ := filePartFromURI("https://example.com/note.mp3", "")
+	err := checkInputModes(context.Background(), []adk.Part{audio})
+	if err == nil || !strings.Contains(err.Error(), "(input modes: text/plain, image/*, application/pdf)") {
+		t.Errorf("Expected the error to list the default and skill input modes, got %v", err)
+	}
+
+	pdf, _ := filePartFromURI("https://example.com/report.pdf", "")
+	if err := checkInputModes(context.Background(), []adk.Part{pdf}); err != nil {
+		t.Errorf("Expected a skill input mode to be accepted, got %v", err)
+	}
+}
@@ -0,0 +1,621 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	cobra "github.com/spf13/cobra"
+	viper "github.com/spf13/viper"
+	zap "go.uber.org/zap"
+
+	adk "github.com/inference-gateway/adk/types"
+)
+
+// maxProxyBodySize limits the request and response bodies the proxy decodes
+const maxProxyBodySize = 32 << 20
+
+// Kinds of traffic observed by the proxy
+const (
+	proxyEntryRequest   = "request"
+	proxyEntryResponse  = "response"
+	proxyEntryEvent     = "event"
+	proxyEntryStreamEnd = "stream-end"
+	proxyEntryError     = "error"
+	proxyEntryFault     = "fault"
+)
+
+// proxyRecordedMethods are the methods whose responses are written to the capture file
+var proxyRecordedMethods = map[string]bool{
+	"message/send":      true,
+	"message/stream":    true,
+	"tasks/resubscribe": true,
+}
+
+// proxyEntry is a single decoded request, response or stream event passing through the proxy
+type proxyEntry struct {
+	Seq        int             `json:"seq"`
+	Time       time.Time       `json:"time"`
+	Kind       string          `json:"kind"`
+	Method     string          `json:"method"`
+	HTTPStatus int             `json:"http_status,omitempty"`
+	TaskID     string          `json:"task_id,omitempty"`
+	EventKind  string          `json:"event_kind,omitempty"`
+	PrevState  string          `json:"prev_state,omitempty"`
+	State      string          `json:"state,omitempty"`
+	Final      bool            `json:"final,omitempty"`
+	Events     int             `json:"events,omitempty"`
+	LatencyMs  int64           `json:"latency_ms,omitempty"`
+	ErrorCode  int             `json:"error_code,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	Fault      string          `json:"fault,omitempty"`
+	Body       json.RawMessage `json:"body,omitempty"`
+
+	latency time.Duration
+}
+
+// proxyExchange tracks a single request forwarded by the proxy
+type proxyExchange struct {
+	seq    int
+	method string
+	rpc    bool
+	taskID string
+	start  time.Time
+	stream int
+}
+
+// proxyExchangeKey stores the exchange of a request in its context
+type proxyExchangeKey struct{}
+
+// jsonRPCRequest is the part of a JSON-RPC request decoded by the proxy
+type jsonRPCRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      any             `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// a2aProxy forwards traffic to an upstream A2A server and reports every decoded
+// request, response and stream event to observe
+type a2aProxy struct {
+	upstream *url.URL
+	reverse  *httputil.ReverseProxy
+	observe  func(proxyEntry)
+	recorder *streamRecorder
+
+	mu     sync.Mutex
+	seq    int
+	states map[string]adk.TaskState
+}
+
+// newA2AProxy creates a proxy to upstream. Responses are passed through as they
+// arrive, so SSE streams reach the client without buffering. When faults is set,
+// its rules are applied to the forwarded traffic.
+func newA2AProxy(upstream *url.URL, transport http.RoundTripper, faults *faultRules, observe func(proxyEntry), recorder *streamRecorder) *a2aProxy {
+	p := &a2aProxy{
+		upstream: upstream,
+		observe:  observe,
+		recorder: recorder,
+		states:   map[string]adk.TaskState{},
+	}
+
+	if faults != nil {
+		transport = newFaultTransport(transport, faults, p.reportFault)
+	}
+
+	p.reverse = &httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			r.SetURL(upstream)
+		},
+		Transport:      transport,
+		FlushInterval:  -1,
+		ModifyResponse: p.inspectResponse,
+		ErrorHandler:   p.handleError,
+	}
+	if errorLog, err := zap.NewStdLogAt(logger, zap.DebugLevel); err == nil {
+		p.reverse.ErrorLog = errorLog
+	}
+
+	return p
+}
+
+func (p *a2aProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ex := &proxyExchange{
+		seq:    p.nextSeq(),
+		method: r.Method + " " + r.URL.Path,
+		start:  time.Now(),
+	}
+	entry := proxyEntry{Kind: proxyEntryRequest}
+
+	if r.Method == http.MethodPost && r.Body != nil {
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxProxyBodySize+1))
+		if err != nil {
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+		r.Body = replayBody(body, r.Body)
+
+		var req jsonRPCRequest
+		if len(body) <= maxProxyBodySize && json.Unmarshal(body, &req) == nil && req.Method != "" {
+			ex.rpc = true
+			ex.method = req.Method
+			ex.taskID = requestTaskID(req.Params)
+			entry.Body = compactJSON(body)
+
+			if p.recorder != nil && proxyRecordedMethods[req.Method] {
+				var params adk.MessageSendParams
+				_ = json.Unmarshal(req.Params, &params)
+				ex.stream = p.recorder.startStream(req.Method, params)
+			}
+		}
+	}
+
+	entry.TaskID = ex.taskID
+	p.emit(ex, entry)
+
+	p.reverse.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), proxyExchangeKey{}, ex)))
+}
+
+// inspectResponse decodes a response on its way back to the client. SSE bodies
+// are decoded while they stream through.
+func (p *a2aProxy) inspectResponse(resp *http.Response) error {
+	ex, ok := resp.Request.Context().Value(proxyExchangeKey{}).(*proxyExchange)
+	if !ok {
+		return nil
+	}
+
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		p.emit(ex, proxyEntry{Kind: proxyEntryResponse, HTTPStatus: resp.StatusCode, TaskID: ex.taskID, latency: time.Since(ex.start)})
+		resp.Body = &proxyStreamTap{body: resp.Body, proxy: p, ex: ex}
+		return nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxProxyBodySize+1))
+	if err != nil {
+		_ = resp.Body.Close()
+		return fmt.Errorf("failed to read upstream response: %w", err)
+	}
+	resp.Body = replayBody(body, resp.Body)
+
+	entry := proxyEntry{Kind: proxyEntryResponse, HTTPStatus: resp.StatusCode, TaskID: ex.taskID, latency: time.Since(ex.start)}
+	if ex.rpc && len(body) <= maxProxyBodySize {
+		entry.Body = compactJSON(body)
+		var rpcResp jsonRPCResponse
+		if json.Unmarshal(body, &rpcResp) == nil {
+			p.decodeResult(ex, &entry, rpcResp)
+		}
+	}
+	p.emit(ex, entry)
+	return nil
+}
+
+// replayBody returns a body that yields the bytes already read from rest before
+// the remainder of rest, so bodies larger than the decoding limit pass through whole
+func replayBody(head []byte, rest io.ReadCloser) io.ReadCloser {
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(head), rest), rest}
+}
+
+// handleError reports a failure to reach the upstream server. Requests dropped
+// by a fault rule close the client connection without a response.
+func (p *a2aProxy) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, errFaultDropped) {
+		panic(http.ErrAbortHandler)
+	}
+	if ex, ok := r.Context().Value(proxyExchangeKey{}).(*proxyExchange); ok {
+		p.emit(ex, proxyEntry{Kind: proxyEntryError, TaskID: ex.taskID, Error: err.Error(), latency: time.Since(ex.start)})
+	}
+	w.WriteHeader(http.StatusBadGateway)
+}
+
+// reportFault logs a fault injected into the traffic of an exchange
+func (p *a2aProxy) reportFault(r *http.Request, _ string, fault string) {
+	if ex, ok := r.Context().Value(proxyExchangeKey{}).(*proxyExchange); ok {
+		p.emit(ex, proxyEntry{Kind: proxyEntryFault, TaskID: ex.taskID, Fault: fault, latency: time.Since(ex.start)})
+	}
+}
+
+// observeStreamEvent decodes a single SSE data payload
+func (p *a2aProxy) observeStreamEvent(ex *proxyExchange, data []byte) {
+	entry := proxyEntry{Kind: proxyEntryEvent, TaskID: ex.taskID, Body: compactJSON(data), latency: time.Since(ex.start)}
+
+	var rpcResp jsonRPCResponse
+	if err := json.Unmarshal(data, &rpcResp); err != nil {
+		entry.Error = "undecodable event: " + err.Error()
+		p.emit(ex, entry)
+		return
+	}
+	p.decodeResult(ex, &entry, rpcResp)
+	p.emit(ex, entry)
+}
+
+// decodeResult fills an entry from a JSON-RPC response, tracks state transitions
+// and records the result when capturing
+func (p *a2aProxy) decodeResult(ex *proxyExchange, entry *proxyEntry, rpcResp jsonRPCResponse) {
+	if rpcResp.Error != nil {
+		entry.ErrorCode = rpcResp.Error.Code
+		entry.Error = rpcResp.Error.Message
+		return
+	}
+	if len(rpcResp.Result) == 0 {
+		return
+	}
+
+	var result any
+	if err := json.Unmarshal(rpcResp.Result, &result); err != nil {
+		return
+	}
+
+	if ex.stream > 0 {
+		p.recorder.recordEvent(ex.stream, adk.JSONRPCSuccessResponse{JSONRPC: rpcResp.JSONRPC, ID: rpcResp.ID, Result: result})
+	}
+
+	_, eventKind, err := classifyStreamEvent(result)
+	if err != nil {
+		return
+	}
+	if entry.Kind == proxyEntryEvent {
+		entry.EventKind = eventKind
+	}
+
+	var info struct {
+		ID     string `json:"id"`
+		TaskID string `json:"taskId"`
+		Final  bool   `json:"final"`
+		Status *struct {
+			State adk.TaskState `json:"state"`
+		} `json:"status"`
+	}
+	if err := json.Unmarshal(rpcResp.Result, &info); err != nil {
+		return
+	}
+
+	switch eventKind {
+	case eventKindTask:
+		entry.TaskID = info.ID
+	case eventKindStatusUpdate, eventKindArtifactUpdate:
+		entry.TaskID = info.TaskID
+	}
+	if entry.TaskID != "" && ex.taskID == "" {
+		ex.taskID = entry.TaskID
+	}
+	entry.Final = info.Final
+
+	if info.Status != nil && info.Status.State != "" && entry.TaskID != "" {
+		entry.State = humanState(info.Status.State)
+		if prev := p.transition(entry.TaskID, info.Status.State); prev != "" && prev != info.Status.State {
+			entry.PrevState = humanState(prev)
+		}
+	}
+}
+
+// transition stores the latest state of a task and returns the previous one
+func (p *a2aProxy) transition(taskID string, state adk.TaskState) adk.TaskState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	prev := p.states[taskID]
+	p.states[taskID] = state
+	return prev
+}
+
+func (p *a2aProxy) nextSeq() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.seq++
+	return p.seq
+}
+
+// emit stamps an entry with its exchange and passes it to the observer
+func (p *a2aProxy) emit(ex *proxyExchange, entry proxyEntry) {
+	entry.Seq = ex.seq
+	entry.Time = time.Now()
+	entry.Method = ex.method
+	entry.LatencyMs = entry.latency.Milliseconds()
+	p.observe(entry)
+}
+
+// proxyStreamTap decodes SSE events from a response body while the client reads it
+type proxyStreamTap struct {
+	body   io.ReadCloser
+	proxy  *a2aProxy
+	ex     *proxyExchange
+	buf    []byte
+	events int
+	once   sync.Once
+}
+
+func (t *proxyStreamTap) Read(b []byte) (int, error) {
+	n, err := t.body.Read(b)
+	if n > 0 {
+		t.scan(b[:n])
+	}
+	if err != nil {
+		t.finish()
+	}
+	return n, err
+}
+
+func (t *proxyStreamTap) Close() error {
+	t.finish()
+	return t.body.Close()
+}
+
+// scan decodes every complete data line received so far
+func (t *proxyStreamTap) scan(chunk []byte) {
+	t.buf = append(t.buf, chunk...)
+	for {
+		idx := bytes.IndexByte(t.buf, '\n')
+		if idx < 0 {
+			break
+		}
+		line := bytes.TrimRight(t.buf[:idx], "\r")
+		t.buf = t.buf[idx+1:]
+
+		data, ok := bytes.CutPrefix(line, []byte("data:"))
+		if !ok {
+			continue
+		}
+		data = bytes.TrimSpace(data)
+		if len(data) == 0 || string(data) == "[DONE]" {
+			continue
+		}
+		t.events++
+		t.proxy.observeStreamEvent(t.ex, data)
+	}
+}
+
+// finish reports the end of the stream once
+func (t *proxyStreamTap) finish() {
+	t.once.Do(func() {
+		t.proxy.emit(t.ex, proxyEntry{Kind: proxyEntryStreamEnd, TaskID: t.ex.taskID, Events: t.events, latency: time.Since(t.ex.start)})
+	})
+}
+
+// requestTaskID extracts the task a request refers to from its params
+func requestTaskID(params json.RawMessage) string {
+	var decoded struct {
+		ID      string `json:"id"`
+		Name    string `json:"name"`
+		Message *struct {
+			TaskID string `json:"taskId"`
+		} `json:"message"`
+	}
+	if err := json.Unmarshal(params, &decoded); err != nil {
+		return ""
+	}
+
+	switch {
+	case decoded.Message != nil:
+		return decoded.Message.TaskID
+	case decoded.ID != "":
+		return decoded.ID
+	default:
+		return strings.TrimPrefix(strings.SplitN(decoded.Name, "/pushNotificationConfigs/", 2)[0], "tasks/")
+	}
+}
+
+// compactJSON returns body without insignificant whitespace, or nil when it is not JSON
+func compactJSON(body []byte) json.RawMessage {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, body); err != nil {
+		return nil
+	}
+	return buf.Bytes()
+}
+
+// printProxyEntry writes an entry as a single log line
+func printProxyEntry(w io.Writer, entry proxyEntry) {
+	fields := []string{entry.Time.Format("15:04:05.000")}
+
+	switch entry.Kind {
+	case proxyEntryRequest:
+		fields = append(fields, "→", fmt.Sprintf("#%d", entry.Seq), entry.Method)
+	case proxyEntryResponse:
+		fields = append(fields, "←", fmt.Sprintf("#%d", entry.Seq), strconv.Itoa(entry.HTTPStatus), entry.Method)
+	case proxyEntryEvent:
+		fields = append(fields, "⇢", fmt.Sprintf("#%d", entry.Seq))
+		if entry.EventKind != eventKindTask {
+			fields = append(fields, entry.EventKind)
+		}
+	case proxyEntryStreamEnd:
+		fields = append(fields, "■", fmt.Sprintf("#%d", entry.Seq), fmt.Sprintf("stream closed after %d event(s) in", entry.Events), formatMs(durationMs(entry.latency)))
+	case proxyEntryError:
+		fields = append(fields, "✖", fmt.Sprintf("#%d", entry.Seq), entry.Method, "upstream error:", entry.Error)
+	case proxyEntryFault:
+		fields = append(fields, "⚡", fmt.Sprintf("#%d", entry.Seq), entry.Method, "injected fault:", entry.Fault)
+	}
+
+	if entry.Kind != proxyEntryStreamEnd && entry.Kind != proxyEntryError && entry.Kind != proxyEntryFault {
+		if entry.TaskID != "" {
+			fields = append(fields, "task "+shortID(entry.TaskID))
+		}
+		switch {
+		case entry.PrevState != "":
+			fields = append(fields, entry.PrevState+" → "+entry.State)
+		case entry.State != "":
+			fields = append(fields, entry.State)
+		}
+		if entry.Final {
+			fields = append(fields, "(final)")
+		}
+		if entry.Error != "" {
+			fields = append(fields, fmt.Sprintf("error %d: %s", entry.ErrorCode, entry.Error))
+		}
+		if entry.Kind == proxyEntryResponse {
+			fields = append(fields, formatMs(durationMs(entry.latency)))
+		}
+	}
+
+	var nonEmpty []string
+	for _, field := range fields {
+		if field != "" {
+			nonEmpty = append(nonEmpty, field)
+		}
+	}
+	fmt.Fprintln(w, strings.Join(nonEmpty, " "))
+}
+
+var proxyCmd = &cobra.Command{
+	Use:   "proxy",
+	Short: "Inspect the traffic between an A2A client and an agent",
+	Long: `Starts a reverse proxy that forwards every request to the upstream A2A server
+and the responses back to the client unchanged, including SSE streams, while
+decoding and logging each JSON-RPC request, response and stream event with its
+method, task ID, state transitions and latency.
+
+Point the client under test at the proxy instead of the agent. Use --capture to
+write message/send, message/stream and tasks/resubscribe traffic to a recording
+that can be played back with replay, and --tui for a live view of the traffic.
+With -o ndjson every entry is printed as a JSON line. The rules given with
+--faults are applied to the forwarded traffic, and every injected fault is logged.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		listen, _ := cmd.Flags().GetString("listen")
+		upstreamURL, _ := cmd.Flags().GetString("upstream")
+		capturePath, _ := cmd.Flags().GetString("capture")
+		useTUI, _ := cmd.Flags().GetBool("tui")
+
+		if upstreamURL == "" {
+			upstreamURL = viper.GetString("server-url")
+		}
+		upstream, err := url.Parse(upstreamURL)
+		if err != nil || upstream.Scheme == "" || upstream.Host == "" {
+			return fmt.Errorf("invalid upstream URL %q", upstreamURL)
+		}
+
+		format, err := getOutputFormat()
+		if err != nil {
+			return err
+		}
+
+		tlsConfig, err := buildTLSConfig()
+		if err != nil {
+			return err
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsConfig
+
+		var faults *faultRules
+		if faultsPath := viper.GetString("faults"); faultsPath != "" {
+			if faults, err = loadFaultRules(faultsPath); err != nil {
+				return err
+			}
+		}
+
+		var recorder *streamRecorder
+		if capturePath != "" {
+			recorder, err = newStreamRecorder(capturePath, "proxy", upstream.String())
+			if err != nil {
+				return err
+			}
+			defer func() { _ = recorder.Close() }()
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		listener, err := net.Listen("tcp", listen)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", listen, err)
+		}
+		proxyURL := webhookURLForListener(listener.Addr(), "")
+
+		var program *tea.Program
+		var requests int
+		var observeMu sync.Mutex
+		observe := func(entry proxyEntry) {
+			observeMu.Lock()
+			defer observeMu.Unlock()
+
+			if entry.Kind == proxyEntryRequest {
+				requests++
+			}
+			switch {
+			case program != nil:
+				program.Send(proxyEntryMsg{entry: entry})
+			case format == OutputFormatNDJSON:
+				printNDJSONLine(entry)
+			default:
+				printProxyEntry(os.Stdout, entry)
+			}
+		}
+		if useTUI {
+			program = tea.NewProgram(newProxyModel(proxyURL, upstream.String()), tea.WithAltScreen())
+		}
+
+		server := &http.Server{
+			Handler:           newA2AProxy(upstream, transport, faults, observe, recorder),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		serverErr := make(chan error, 1)
+		go func() {
+			serverErr <- server.Serve(listener)
+		}()
+
+		if program != nil {
+			go func() {
+				select {
+				case <-ctx.Done():
+					program.Quit()
+				case err := <-serverErr:
+					serverErr <- err
+					program.Quit()
+				}
+			}()
+			if _, err := program.Run(); err != nil {
+				return err
+			}
+		} else {
+			if format != OutputFormatNDJSON {
+				fmt.Printf("🔀 Proxying %s → %s\n", proxyURL, upstream)
+				if capturePath != "" {
+					fmt.Printf("💾 Capturing streams to %s\n", capturePath)
+				}
+				if faults != nil {
+					fmt.Printf("⚡ Injecting faults from %d rule(s)\n", len(faults.Rules))
+				}
+				fmt.Printf("\nPress Ctrl+C to stop.\n\n")
+			}
+
+			select {
+			case err := <-serverErr:
+				if !errors.Is(err, http.ErrServerClosed) {
+					return fmt.Errorf("proxy server failed: %w", err)
+				}
+			case <-ctx.Done():
+			}
+		}
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logger.Warn("Failed to shut down proxy server", zap.Error(err))
+		}
+
+		observeMu.Lock()
+		defer observeMu.Unlock()
+		if format != OutputFormatNDJSON {
+			fmt.Printf("\n📋 Proxied %d request(s)\n", requests)
+		}
+		return nil
+	},
+}
@@ -0,0 +1,286 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	client "github.com/inference-gateway/adk/client"
+	adk "github.com/inference-gateway/adk/types"
+	"go.uber.org/zap"
+)
+
+// proxyEntries collects the entries observed by a proxy under test
+type proxyEntries struct {
+	mu      sync.Mutex
+	entries []proxyEntry
+}
+
+func (c *proxyEntries) observe(entry proxyEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = append(c.entries, entry)
+}
+
+func (c *proxyEntries) ofKind(kind string) []proxyEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var matching []proxyEntry
+	for _, entry := range c.entries {
+		if entry.Kind == kind {
+			matching = append(matching, entry)
+		}
+	}
+	return matching
+}
+
+func newTestProxy(t *testing.T, upstreamURL string, faults *faultRules, recorder *streamRecorder) (client.A2AClient, *proxyEntries) {
+	t.Helper()
+
+	originalLogger := logger
+	logger = zap.NewNop()
+	t.Cleanup(func() { logger = originalLogger })
+
+	upstream, err := url.Parse(upstreamURL)
+	if err != nil {
+		t.Fatalf("invalid upstream URL: %v", err)
+	}
+
+	collected := &proxyEntries{}
+	proxy := httptest.NewServer(newA2AProxy(upstream, http.DefaultTransport, faults, collected.observe, recorder))
+	t.Cleanup(proxy.Close)
+
+	return client.NewClientWithLogger(proxy.URL, zap.NewNop()), collected
+}
+
+func newTestUpstream(t *testing.T, scenario string) string {
+	t.Helper()
+
+	loaded, err := loadMockScenario(writeMockScenario(t, scenario))
+	if err != nil {
+		t.Fatalf("loadMockScenario failed: %v", err)
+	}
+	upstream := httptest.NewServer(newMockServer(loaded, io.Discard))
+	t.Cleanup(upstream.Close)
+	return upstream.URL
+}
+
+func TestA2AProxy_SendAndErrors(t *testing.T) {
+	a2a, collected := newTestProxy(t, newTestUpstream(t, testMockScenario), nil, nil)
+	ctx := context.Background()
+
+	text := "What is the weather in Berlin?"
+	resp, err := a2a.SendTask(ctx, adk.MessageSendParams{Message: adk.Message{MessageID: "m1", Role: adk.RoleUser, Parts: []adk.Part{{Text: &text}}}})
+	if err != nil {
+		t.Fatalf("SendTask through the proxy failed: %v", err)
+	}
+	task, _ := taskFromResult(resp.Result)
+	if task.Status.State != adk.TaskStateCompleted {
+		t.Fatalf("Expected the upstream response to pass through, got %+v", task)
+	}
+
+	if _, err := a2a.GetTask(ctx, adk.TaskQueryParams{ID: "missing"}); err == nil {
+		t.Fatal("Expected the upstream error to pass through")
+	}
+	if _, err := a2a.GetAgentCard(ctx); err != nil {
+		t.Fatalf("GetAgentCard through the proxy failed: %v", err)
+	}
+
+	requests := collected.ofKind(proxyEntryRequest)
+	responses := collected.ofKind(proxyEntryResponse)
+	if len(requests) != 3 || len(responses) != 3 {
+		t.Fatalf("Expected three requests and responses, got %d and %d", len(requests), len(responses))
+	}
+	if requests[0].Method != "message/send" || len(requests[0].Body) == 0 {
+		t.Errorf("Expected the decoded JSON-RPC request, got %+v", requests[0])
+	}
+	if responses[0].TaskID != task.ID || responses[0].State != "completed" || responses[0].HTTPStatus != http.StatusOK {
+		t.Errorf("Expected the task and its state on the response, got %+v", responses[0])
+	}
+	if requests[1].TaskID != "missing" || responses[1].ErrorCode != jsonRPCTaskNotFound {
+		t.Errorf("Expected the task not found error to be decoded, got %+v / %+v", requests[1], responses[1])
+	}
+	if requests[2].Method != "GET /.well-known/agent-card.json" {
+		t.Errorf("Expected plain HTTP requests to be logged by path, got %q", requests[2].Method)
+	}
+}
+
+func TestA2AProxy_StreamingAndCapture(t *testing.T) {
+	capture := filepath.Join(t.TempDir(), "capture.jsonl")
+	recorder, err := newStreamRecorder(capture, "proxy", "http://agent")
+	if err != nil {
+		t.Fatalf("newStreamRecorder failed: %v", err)
+	}
+
+	a2a, collected := newTestProxy(t, newTestUpstream(t, testMockScenario), nil, recorder)
+
+	text := "weather please"
+	events, err := a2a.SendTaskStreaming(context.Background(), adk.MessageSendParams{Message: adk.Message{MessageID: "m1", Role: adk.RoleUser, Parts: []adk.Part{{Text: &text}}}})
+	if err != nil {
+		t.Fatalf("SendTaskStreaming through the proxy failed: %v", err)
+	}
+	received := 0
+	for range events {
+		received++
+	}
+	if received != 4 {
+		t.Fatalf("Expected all four events to pass through, got %d", received)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(collected.ofKind(proxyEntryStreamEnd)) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	_ = recorder.Close()
+
+	streamEvents := collected.ofKind(proxyEntryEvent)
+	if len(streamEvents) != 4 {
+		t.Fatalf("Expected four decoded events, got %d", len(streamEvents))
+	}
+	last := streamEvents[3]
+	if last.EventKind != eventKindStatusUpdate || last.PrevState != "working" || last.State != "completed" || !last.Final {
+		t.Errorf("Expected the final transition working → completed, got %+v", last)
+	}
+	if ends := collected.ofKind(proxyEntryStreamEnd); len(ends) != 1 || ends[0].Events != 4 {
+		t.Errorf("Expected the stream end with its event count, got %+v", ends)
+	}
+
+	rec, err := readRecording(capture)
+	if err != nil {
+		t.Fatalf("Expected a replayable capture, got %v", err)
+	}
+	if rec.Source != "proxy" || len(rec.Streams) != 1 || len(rec.Streams[0].Events) != 4 || rec.Streams[0].Method != "message/stream" {
+		t.Errorf("Unexpected capture: %+v", rec)
+	}
+}
+
+func TestA2AProxy_UpstreamDown(t *testing.T) {
+	upstream := httptest.NewServer(http.NotFoundHandler())
+	upstreamURL := upstream.URL
+	upstream.Close()
+
+	a2a, collected := newTestProxy(t, upstreamURL, nil, nil)
+	if _, err := a2a.GetTask(context.Background(), adk.TaskQueryParams{ID: "t1"}); err == nil || !strings.Contains(err.Error(), "502") {
+		t.Errorf("Expected a bad gateway error, got %v", err)
+	}
+	if errs := collected.ofKind(proxyEntryError); len(errs) != 1 || errs[0].Method != "tasks/get" {
+		t.Errorf("Expected the upstream failure to be reported, got %+v", errs)
+	}
+}
+
+func TestPrintProxyEntry(t *testing.T) {
+	at := time.Date(2025, 3, 4, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		entry    proxyEntry
+		expected string
+	}{
+		{proxyEntry{Time: at, Seq: 1, Kind: proxyEntryRequest, Method: "message/stream"}, "10:30:00.000 → #1 message/stream\n"},
+		{proxyEntry{Time: at, Seq: 2, Kind: proxyEntryResponse, Method: "tasks/get", HTTPStatus: 200, ErrorCode: -32001, Error: "Task not found", latency: 1500 * time.Microsecond}, "10:30:00.000 ← #2 200 tasks/get error -32001: Task not found 1.5ms\n"},
+		{proxyEntry{Time: at, Seq: 1, Kind: proxyEntryEvent, EventKind: eventKindStatusUpdate, TaskID: "0123456789", PrevState: "working", State: "completed", Final: true}, "10:30:00.000 ⇢ #1 status-update task 01234567 working → completed (final)\n"},
+		{proxyEntry{Time: at, Seq: 1, Kind: proxyEntryStreamEnd, Events: 3, latency: 2 * time.Second}, "10:30:00.000 ■ #1 stream closed after 3 event(s) in 2000.0ms\n"},
+	}
+	for _, tt := range tests {
+		var b strings.Builder
+		printProxyEntry(&b, tt.entry)
+		if b.String() != tt.expected {
+			t.Errorf("Expected %q, got %q", tt.expected, b.String())
+		}
+	}
+}
+
+func TestRequestTaskID(t *testing.T) {
+	tests := map[string]string{
+		`{"message":{"messageId":"m1","taskId":"t1"}}`:   "t1",
+		`{"message":{"messageId":"m1"}}`:                 "",
+		`{"id":"t2","historyLength":2}`:                  "t2",
+		`{"name":"tasks/t3/pushNotificationConfigs/c1"}`: "t3",
+		`not json`: "",
+	}
+	for params, expected := range tests {
+		if got := requestTaskID([]byte(params)); got != expected {
+			t.Errorf("requestTaskID(%s) = %q, expected %q", params, got, expected)
+		}
+	}
+}
+
+func TestProxyModel(t *testing.T) {
+	var model tea.Model = newProxyModel("http://localhost:8081", "http://localhost:8080")
+	model, _ = model.Update(tea.WindowSizeMsg{Width: 100, Height: 20})
+	for i := 1; i <= 3; i++ {
+		model, _ = model.Update(proxyEntryMsg{entry: proxyEntry{Seq: i, Kind: proxyEntryRequest, Method: "tasks/get"}})
+	}
+	model, _ = model.Update(proxyEntryMsg{entry: proxyEntry{Seq: 3, Kind: proxyEntryResponse, Method: "tasks/get", HTTPStatus: 500}})
+
+	m := model.(proxyModel)
+	if m.requests != 3 || m.errors != 1 || m.cursor != 3 || !m.follow {
+		t.Fatalf("Expected the view to follow new entries, got requests=%d errors=%d cursor=%d", m.requests, m.errors, m.cursor)
+	}
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyUp})
+	model, _ = model.Update(proxyEntryMsg{entry: proxyEntry{Seq: 4, Kind: proxyEntryRequest, Method: "tasks/list"}})
+	if m = model.(proxyModel); m.cursor != 2 || m.follow {
+		t.Errorf("Expected the selection to stay put after moving up, got cursor=%d follow=%v", m.cursor, m.follow)
+	}
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if view := model.View(); !strings.Contains(view, "A2A Proxy") || !strings.Contains(view, "no JSON body") {
+		t.Errorf("Expected the header and the detail pane, got:\n%s", view)
+	}
+}
+
+func TestA2AProxy_LargeBodies(t *testing.T) {
+	size := int64(maxProxyBodySize + 1024)
+
+	var received int64
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.Copy(w, io.LimitReader(neverEnding('x'), size))
+	}))
+	defer upstream.Close()
+
+	originalLogger := logger
+	logger = zap.NewNop()
+	defer func() { logger = originalLogger }()
+
+	upstreamURL, _ := url.Parse(upstream.URL)
+	collected := &proxyEntries{}
+	proxy := httptest.NewServer(newA2AProxy(upstreamURL, http.DefaultTransport, nil, collected.observe, nil))
+	defer proxy.Close()
+
+	resp, err := http.Post(proxy.URL+"/a2a", "application/json", io.LimitReader(neverEnding('y'), size))
+	if err != nil {
+		t.Fatalf("POST through the proxy failed: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	forwarded, err := io.Copy(io.Discard, resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read the response: %v", err)
+	}
+	if received != size || forwarded != size {
+		t.Errorf("Expected %d bytes in both directions, upstream got %d and the client got %d", size, received, forwarded)
+	}
+	if requests := collected.ofKind(proxyEntryRequest); len(requests) != 1 || requests[0].Body != nil {
+		t.Errorf("Expected the oversized request to be logged without its body, got %+v", requests)
+	}
+}
+
+// neverEnding is an endless reader of a single byte
+type neverEnding byte
+
+func (b neverEnding) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = byte(b)
+	}
+	return len(p), nil
+}
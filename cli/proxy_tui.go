@@ -0,0 +1,206 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	lipgloss "github.com/charmbracelet/lipgloss"
+)
+
+// maxProxyTUIEntries is how many entries the live proxy view keeps
+const maxProxyTUIEntries = 1000
+
+// proxyEntryMsg delivers an entry observed by the proxy to the live view.
+type proxyEntryMsg struct {
+	entry proxyEntry
+}
+
+var (
+	selectedStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#FFFFFF")).Background(lipgloss.Color("#3C3C5A"))
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5F87"))
+)
+
+// proxyModel is the Bubble Tea model of the live proxy view.
+type proxyModel struct {
+	listenURL   string
+	upstreamURL string
+
+	entries  []proxyEntry
+	cursor   int
+	follow   bool
+	detail   bool
+	requests int
+	errors   int
+
+	width  int
+	height int
+}
+
+func newProxyModel(listenURL, upstreamURL string) proxyModel {
+	return proxyModel{
+		listenURL:   listenURL,
+		upstreamURL: upstreamURL,
+		follow:      true,
+	}
+}
+
+func (m proxyModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m proxyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case proxyEntryMsg:
+		m.addEntry(msg.entry)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc", "q":
+			return m, tea.Quit
+		case "up", "k":
+			m.moveCursor(-1)
+		case "down", "j":
+			m.moveCursor(1)
+		case "pgup":
+			m.moveCursor(-m.listHeight())
+		case "pgdown":
+			m.moveCursor(m.listHeight())
+		case "home", "g":
+			m.moveCursor(-len(m.entries))
+		case "end", "G":
+			m.moveCursor(len(m.entries))
+		case "enter":
+			m.detail = !m.detail
+		case "c":
+			m.entries = nil
+			m.cursor = 0
+			m.follow = true
+		}
+	}
+	return m, nil
+}
+
+// addEntry appends an entry, dropping the oldest ones beyond maxProxyTUIEntries.
+func (m *proxyModel) addEntry(entry proxyEntry) {
+	switch {
+	case entry.Kind == proxyEntryRequest:
+		m.requests++
+	case entry.Kind == proxyEntryError, entry.Error != "", entry.HTTPStatus >= 400:
+		m.errors++
+	}
+
+	m.entries = append(m.entries, entry)
+	if overflow := len(m.entries) - maxProxyTUIEntries; overflow > 0 {
+		m.entries = m.entries[overflow:]
+		m.cursor = max(m.cursor-overflow, 0)
+	}
+	if m.follow {
+		m.cursor = len(m.entries) - 1
+	}
+}
+
+// moveCursor moves the selection; selecting the newest entry follows new traffic again.
+func (m *proxyModel) moveCursor(delta int) {
+	if len(m.entries) == 0 {
+		return
+	}
+	m.cursor = min(max(m.cursor+delta, 0), len(m.entries)-1)
+	m.follow = m.cursor == len(m.entries)-1
+}
+
+func (m proxyModel) headerView() string {
+	title := titleStyle.Render("A2A Proxy")
+	meta := metaStyle.Render(fmt.Sprintf("%s → %s · %d requests · %d errors", m.listenURL, m.upstreamURL, m.requests, m.errors))
+	return lipgloss.JoinHorizontal(lipgloss.Center, title, " ", meta)
+}
+
+func (m proxyModel) footerView() string {
+	follow := "paused"
+	if m.follow {
+		follow = "following"
+	}
+	return dimStyle.Render(follow + " · ↑/↓: select · enter: toggle details · c: clear · G: follow · q: quit")
+}
+
+// listHeight is the number of rows available for the entry list.
+func (m proxyModel) listHeight() int {
+	height := m.height - lipgloss.Height(m.headerView()) - lipgloss.Height(m.footerView())
+	if m.detail {
+		height /= 2
+	}
+	return max(height, 3)
+}
+
+func (m proxyModel) listView() string {
+	height := m.listHeight()
+	if len(m.entries) == 0 {
+		return systemStyle.Render("waiting for traffic...") + strings.Repeat("\n", height-1)
+	}
+
+	start := max(m.cursor-height+1, 0)
+	end := min(start+height, len(m.entries))
+
+	rows := make([]string, 0, height)
+	for i := start; i < end; i++ {
+		var b strings.Builder
+		printProxyEntry(&b, m.entries[i])
+		row := previewText(strings.TrimRight(b.String(), "\n"), max(m.width-1, 20))
+
+		entry := m.entries[i]
+		switch {
+		case i == m.cursor:
+			row = selectedStyle.Render(row)
+		case entry.Kind == proxyEntryError || entry.Error != "" || entry.HTTPStatus >= 400:
+			row = errorStyle.Render(row)
+		}
+		rows = append(rows, row)
+	}
+	for len(rows) < height {
+		rows = append(rows, "")
+	}
+	return strings.Join(rows, "\n")
+}
+
+func (m proxyModel) detailView() string {
+	height := max(m.height-lipgloss.Height(m.headerView())-lipgloss.Height(m.footerView())-m.listHeight()-1, 3)
+	if len(m.entries) == 0 {
+		return ""
+	}
+
+	entry := m.entries[m.cursor]
+	body := dimStyle.Render("no JSON body")
+	if len(entry.Body) > 0 {
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, entry.Body, "", "  "); err == nil {
+			body = bodyStyle.Render(indented.String())
+		}
+	}
+
+	lines := strings.Split(body, "\n")
+	if len(lines) > height {
+		lines = append(lines[:height-1], dimStyle.Render(fmt.Sprintf("… %d more line(s)", len(lines)-height+1)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m proxyModel) View() string {
+	if m.width == 0 {
+		return "initializing..."
+	}
+
+	views := []string{m.headerView(), m.listView()}
+	if m.detail {
+		views = append(views, dimStyle.Render(strings.Repeat("─", max(m.width, 1))), m.detailView())
+	}
+	views = append(views, m.footerView())
+	return strings.Join(views, "\n")
+}
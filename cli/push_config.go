@@ -0,0 +1,218 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	cobra "github.com/spf13/cobra"
+	zap "go.uber.org/zap"
+
+	adk "github.com/inference-gateway/adk/types"
+)
+
+// Push notification config namespace command
+var pushConfigCmd = &cobra.Command{
+	Use:   "push-config",
+	Short: "Push notification configuration commands",
+	Long:  "Commands for managing the push notification (webhook) configurations attached to A2A tasks.",
+}
+
+var pushConfigSetCmd = &cobra.Command{
+	Use:   "set [task-id]",
+	Short: "Set the push notification config of a task",
+	Long:  "Registers or replaces a webhook that the A2A server notifies when the task is updated.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := rejectTableOutput(); err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		ensureA2AClient()
+
+		taskID := args[0]
+		webhookURL, _ := cmd.Flags().GetString("url")
+		configID, _ := cmd.Flags().GetString("config-id")
+		token, _ := cmd.Flags().GetString("notification-token")
+		authSchemes, _ := cmd.Flags().GetStringSlice("auth-scheme")
+		authCredentials, _ := cmd.Flags().GetString("auth-credentials")
+
+		if webhookURL == "" {
+			return fmt.Errorf("--url is required")
+		}
+
+		config, err := buildPushNotificationConfig(webhookURL, configID, token, authSchemes, authCredentials)
+		if err != nil {
+			return err
+		}
+
+		params := adk.TaskPushNotificationConfig{
+			Name:                   taskID,
+			PushNotificationConfig: config,
+		}
+
+		logger.Debug("Setting push notification config", zap.String("task_id", taskID), zap.String("url", webhookURL))
+
+		resp, err := a2aClient.SetTaskPushNotificationConfig(ctx, params)
+		if err != nil {
+			return handleA2AError(err, "tasks/pushNotificationConfig/set")
+		}
+
+		var result adk.TaskPushNotificationConfig
+		if err := decodeResult(resp.Result, &result); err != nil {
+			return err
+		}
+
+		return printFormatted(result)
+	},
+}
+
+var pushConfigGetCmd = &cobra.Command{
+	Use:   "get [task-id]",
+	Short: "Get the push notification config of a task",
+	Long:  "Retrieves the push notification configuration attached to a task.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		ensureA2AClient()
+
+		taskID := args[0]
+
+		logger.Debug("Getting push notification config", zap.String("task_id", taskID))
+
+		resp, err := a2aClient.GetTaskPushNotificationConfig(ctx, adk.GetTaskPushNotificationConfigParams{Name: taskID})
+		if err != nil {
+			return handleA2AError(err, "tasks/pushNotificationConfig/get")
+		}
+
+		var result adk.TaskPushNotificationConfig
+		if err := decodeResult(resp.Result, &result); err != nil {
+			return err
+		}
+
+		return printFormatted(result)
+	},
+}
+
+var pushConfigListCmd = &cobra.Command{
+	Use:   "list [task-id]",
+	Short: "List the push notification configs of a task",
+	Long:  "Lists every push notification configuration attached to a task.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		ensureA2AClient()
+
+		taskID := args[0]
+		pageSize, _ := cmd.Flags().GetInt("page-size")
+		pageToken, _ := cmd.Flags().GetString("page-token")
+
+		params := adk.ListTaskPushNotificationConfigParams{
+			Parent:    taskID,
+			PageSize:  pageSize,
+			PageToken: pageToken,
+		}
+
+		logger.Debug("Listing push notification configs", zap.Any("params", params))
+
+		resp, err := a2aClient.ListTaskPushNotificationConfig(ctx, params)
+		if err != nil {
+			return handleA2AError(err, "tasks/pushNotificationConfig/list")
+		}
+
+		list, err := pushConfigListFromResult(resp.Result)
+		if err != nil {
+			return err
+		}
+
+		output := map[string]any{
+			"task_id": taskID,
+			"configs": list.Configs,
+		}
+		if list.NextPageToken != "" {
+			output["next_page_token"] = list.NextPageToken
+		}
+
+		return printFormatted(output)
+	},
+}
+
+var pushConfigDeleteCmd = &cobra.Command{
+	Use:   "delete [task-id]",
+	Short: "Delete the push notification config of a task",
+	Long:  "Removes the push notification configuration attached to a task.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := rejectTableOutput(); err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		ensureA2AClient()
+
+		taskID := args[0]
+
+		logger.Debug("Deleting push notification config", zap.String("task_id", taskID))
+
+		if _, err := a2aClient.DeleteTaskPushNotificationConfig(ctx, adk.DeleteTaskPushNotificationConfigParams{Name: taskID}); err != nil {
+			return handleA2AError(err, "tasks/pushNotificationConfig/delete")
+		}
+
+		output := map[string]any{
+			"deleted": true,
+			"task_id": taskID,
+		}
+
+		return printFormatted(output)
+	},
+}
+
+// buildPushNotificationConfig assembles a push notification config from CLI flag values
+func buildPushNotificationConfig(webhookURL, configID, token string, authSchemes []string, authCredentials string) (adk.PushNotificationConfig, error) {
+	config := adk.PushNotificationConfig{
+		URL: webhookURL,
+	}
+
+	if configID != "" {
+		config.ID = &configID
+	}
+
+	if token != "" {
+		config.Token = &token
+	}
+
+	if len(authSchemes) > 0 {
+		config.Authentication = &adk.AuthenticationInfo{
+			Schemes: authSchemes,
+		}
+		if authCredentials != "" {
+			config.Authentication.Credentials = &authCredentials
+		}
+	} else if authCredentials != "" {
+		return config, fmt.Errorf("--auth-credentials requires --auth-scheme")
+	}
+
+	return config, nil
+}
+
+// pushConfigListFromResult decodes a push config list result. Some servers return a
+// bare array of configs while others wrap it in a ListTaskPushNotificationConfigResponse.
+func pushConfigListFromResult(result any) (adk.ListTaskPushNotificationConfigResponse, error) {
+	var list adk.ListTaskPushNotificationConfigResponse
+
+	resultBytes, err := json.Marshal(result)
+	if err != nil {
+		return list, fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	if err := json.Unmarshal(resultBytes, &list.Configs); err == nil {
+		return list, nil
+	}
+
+	if err := json.Unmarshal(resultBytes, &list); err != nil {
+		return list, fmt.Errorf("failed to unmarshal push notification configs: %w", err)
+	}
+
+	return list, nil
+}
@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	adk "github.com/inference-gateway/adk/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+func TestBuildPushNotificationConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		configID    string
+		token       string
+		schemes     []string
+		credentials string
+		expectError bool
+	}{
+		{
+			name: "URL only",
+		},
+		{
+			name:        "Token and bearer authentication",
+			configID:    "cfg-1",
+			token:       "secret",
+			schemes:     []string{"bearer"},
+			credentials: "jwt-token",
+		},
+		{
+			name:        "Credentials without scheme",
+			credentials: "jwt-token",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := buildPushNotificationConfig("http://localhost:9000/webhook", tt.configID, tt.token, tt.schemes, tt.credentials)
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if config.URL != "http://localhost:9000/webhook" {
+				t.Errorf("Expected webhook URL to be set, got %q", config.URL)
+			}
+			if (tt.configID != "") != (config.ID != nil) {
+				t.Errorf("Expected config ID presence %v, got %v", tt.configID != "", config.ID != nil)
+			}
+			if (tt.token != "") != (config.Token != nil) {
+				t.Errorf("Expected token presence %v, got %v", tt.token != "", config.Token != nil)
+			}
+			if len(tt.schemes) > 0 {
+				if config.Authentication == nil || config.Authentication.Credentials == nil {
+					t.Fatal("Expected authentication with credentials")
+				}
+				if *config.Authentication.Credentials != tt.credentials {
+					t.Errorf("Expected credentials %q, got %q", tt.credentials, *config.Authentication.Credentials)
+				}
+			} else if config.Authentication != nil {
+				t.Error("Expected no authentication")
+			}
+		})
+	}
+}
+
+func TestPushConfigListFromResult(t *testing.T) {
+	config := map[string]any{
+		"name":                   "task-1",
+		"pushNotificationConfig": map[string]any{"url": "http://localhost:9000/webhook"},
+	}
+
+	tests := []struct {
+		name   string
+		result any
+	}{
+		{
+			name:   "Bare array",
+			result: []any{config},
+		},
+		{
+			name:   "Wrapped response",
+			result: map[string]any{"configs": []any{config}, "nextPageToken": ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := pushConfigListFromResult(tt.result)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(list.Configs) != 1 {
+				t.Fatalf("Expected 1 config, got %d", len(list.Configs))
+			}
+			if list.Configs[0].PushNotificationConfig.URL != "http://localhost:9000/webhook" {
+				t.Errorf("Unexpected webhook URL %q", list.Configs[0].PushNotificationConfig.URL)
+			}
+		})
+	}
+}
+
+func TestPushConfigSetCmd(t *testing.T) {
+	originalClient := a2aClient
+	originalLogger := logger
+	logger = zap.NewNop()
+
+	var sent adk.TaskPushNotificationConfig
+	a2aClient = &mockA2AClient{
+		setPushConfigFunc: func(ctx context.Context, params adk.TaskPushNotificationConfig) (*adk.JSONRPCSuccessResponse, error) {
+			sent = params
+			return &adk.JSONRPCSuccessResponse{Result: params}, nil
+		},
+	}
+
+	viper.Set("output", "json")
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("url", "", "")
+	cmd.Flags().String("config-id", "", "")
+	cmd.Flags().String("notification-token", "", "")
+	cmd.Flags().StringSlice("auth-scheme", nil, "")
+	cmd.Flags().String("auth-credentials", "", "")
+	_ = cmd.Flag("url").Value.Set("http://localhost:9000/webhook")
+	_ = cmd.Flag("notification-token").Value.Set("secret")
+
+	err := pushConfigSetCmd.RunE(cmd, []string{"task-1"})
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r)
+
+	a2aClient = originalClient
+	logger = originalLogger
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if sent.Name != "task-1" {
+		t.Errorf("Expected config for task-1, got %q", sent.Name)
+	}
+	if sent.PushNotificationConfig.Token == nil || *sent.PushNotificationConfig.Token != "secret" {
+		t.Error("Expected token to be sent")
+	}
+
+	var result adk.TaskPushNotificationConfig
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if result.PushNotificationConfig.URL != "http://localhost:9000/webhook" {
+		t.Errorf("Unexpected webhook URL in output %q", result.PushNotificationConfig.URL)
+	}
+}
@@ -0,0 +1,320 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	cobra "github.com/spf13/cobra"
+	zap "go.uber.org/zap"
+
+	adk "github.com/inference-gateway/adk/types"
+)
+
+// recordingVersion is the version of the stream recording format written by --record
+const recordingVersion = 1
+
+// Recording line types
+const (
+	recordingLineHeader = "header"
+	recordingLineStream = "stream"
+	recordingLineEvent  = "event"
+)
+
+// recordingLine is a single JSON line of a stream recording. Every recording
+// starts with a header line, followed by a stream line for each streaming
+// request and an event line for each response received on that stream.
+type recordingLine struct {
+	Type       string                      `json:"type"`
+	Version    int                         `json:"version,omitempty"`
+	RecordedAt *time.Time                  `json:"recorded_at,omitempty"`
+	ServerURL  string                      `json:"server_url,omitempty"`
+	Source     string                      `json:"source,omitempty"`
+	Stream     int                         `json:"stream,omitempty"`
+	OffsetMs   int64                       `json:"offset_ms"`
+	Method     string                      `json:"method,omitempty"`
+	Params     *adk.MessageSendParams      `json:"params,omitempty"`
+	Response   *adk.JSONRPCSuccessResponse `json:"response,omitempty"`
+}
+
+// streamRecorder writes streaming responses with their timing relative to the
+// start of the recording
+type streamRecorder struct {
+	mu      sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+	start   time.Time
+	streams int
+}
+
+// newStreamRecorder creates the recording file and writes its header
+func newStreamRecorder(path, source, serverURL string) (*streamRecorder, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recording file: %w", err)
+	}
+
+	r := &streamRecorder{
+		file:    file,
+		encoder: json.NewEncoder(file),
+		start:   time.Now(),
+	}
+
+	recordedAt := r.start.UTC()
+	if err := r.encoder.Encode(recordingLine{
+		Type:       recordingLineHeader,
+		Version:    recordingVersion,
+		RecordedAt: &recordedAt,
+		ServerURL:  serverURL,
+		Source:     source,
+	}); err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to write recording header: %w", err)
+	}
+
+	return r, nil
+}
+
+// startStream records the request that opened a new stream and returns its number
+func (r *streamRecorder) startStream(method string, params adk.MessageSendParams) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.streams++
+	r.write(recordingLine{
+		Type:   recordingLineStream,
+		Stream: r.streams,
+		Method: method,
+		Params: &params,
+	})
+	return r.streams
+}
+
+// tee records every response received on in and forwards it on the returned channel
+func (r *streamRecorder) tee(stream int, in <-chan adk.JSONRPCSuccessResponse) <-chan adk.JSONRPCSuccessResponse {
+	out := make(chan adk.JSONRPCSuccessResponse)
+
+	go func() {
+		defer close(out)
+		for resp := range in {
+			r.recordEvent(stream, resp)
+			out <- resp
+		}
+	}()
+
+	return out
+}
+
+// recordEvent records a single response received on a stream
+func (r *streamRecorder) recordEvent(stream int, resp adk.JSONRPCSuccessResponse) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.write(recordingLine{
+		Type:     recordingLineEvent,
+		Stream:   stream,
+		Response: &resp,
+	})
+}
+
+// write stamps a line with the current offset and appends it to the recording.
+// Callers must hold r.mu.
+func (r *streamRecorder) write(line recordingLine) {
+	line.OffsetMs = time.Since(r.start).Milliseconds()
+	if err := r.encoder.Encode(line); err != nil {
+		logger.Error("Failed to write recording", zap.Error(err))
+	}
+}
+
+// Close closes the recording file
+func (r *streamRecorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
+
+// recordedStream is a single stream read back from a recording
+type recordedStream struct {
+	Method   string
+	Params   *adk.MessageSendParams
+	OffsetMs int64
+	Events   []recordingLine
+}
+
+// recording is a stream recording read back from disk
+type recording struct {
+	RecordedAt *time.Time
+	ServerURL  string
+	Source     string
+	Streams    []*recordedStream
+}
+
+// readRecording parses a stream recording file
+func readRecording(path string) (*recording, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	rec := &recording{}
+	streams := map[int]*recordedStream{}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+
+		var line recordingLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			return nil, fmt.Errorf("invalid recording line %d: %w", lineNo, err)
+		}
+
+		if lineNo == 1 {
+			if line.Type != recordingLineHeader {
+				return nil, fmt.Errorf("invalid recording: missing header line")
+			}
+			if line.Version != recordingVersion {
+				return nil, fmt.Errorf("unsupported recording version %d (expected %d)", line.Version, recordingVersion)
+			}
+			rec.RecordedAt = line.RecordedAt
+			rec.ServerURL = line.ServerURL
+			rec.Source = line.Source
+			continue
+		}
+
+		switch line.Type {
+		case recordingLineStream:
+			stream := &recordedStream{Method: line.Method, Params: line.Params, OffsetMs: line.OffsetMs}
+			streams[line.Stream] = stream
+			rec.Streams = append(rec.Streams, stream)
+		case recordingLineEvent:
+			stream, ok := streams[line.Stream]
+			if !ok {
+				return nil, fmt.Errorf("invalid recording line %d: event for unknown stream %d", lineNo, line.Stream)
+			}
+			if line.Response == nil {
+				return nil, fmt.Errorf("invalid recording line %d: event without response", lineNo)
+			}
+			stream.Events = append(stream.Events, line)
+		default:
+			return nil, fmt.Errorf("invalid recording line %d: unknown type %q", lineNo, line.Type)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+	if lineNo == 0 {
+		return nil, fmt.Errorf("invalid recording: file is empty")
+	}
+
+	return rec, nil
+}
+
+// parseReplaySpeed parses a replay speed such as "1x" or "10x". An empty value
+// replays events without delay.
+func parseReplaySpeed(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+
+	speed, err := strconv.ParseFloat(strings.TrimSuffix(strings.ToLower(value), "x"), 64)
+	if err != nil || speed <= 0 {
+		return 0, fmt.Errorf("invalid speed %q (expected a positive multiplier such as 1x or 10x)", value)
+	}
+	return speed, nil
+}
+
+// replayStream feeds the recorded events of a stream into a channel, waiting
+// for the recorded time between events scaled by speed. A speed of zero
+// replays without delay.
+func replayStream(stream *recordedStream, speed float64) <-chan adk.JSONRPCSuccessResponse {
+	ch := make(chan adk.JSONRPCSuccessResponse)
+
+	go func() {
+		defer close(ch)
+		previous := stream.OffsetMs
+		for _, event := range stream.Events {
+			if speed > 0 && event.OffsetMs > previous {
+				time.Sleep(time.Duration(float64(event.OffsetMs-previous) / speed * float64(time.Millisecond)))
+			}
+			previous = event.OffsetMs
+			ch <- *event.Response
+		}
+	}()
+
+	return ch
+}
+
+// recordedDuration is the time between the request and the last event of a stream
+func (s *recordedStream) recordedDuration() time.Duration {
+	if len(s.Events) == 0 {
+		return 0
+	}
+	return time.Duration(s.Events[len(s.Events)-1].OffsetMs-s.OffsetMs) * time.Millisecond
+}
+
+var replayCmd = &cobra.Command{
+	Use:   "replay [file]",
+	Short: "Replay a recorded streaming session without contacting a server",
+	Long: `Replays a session recorded with --record on tasks submit-streaming or interactive.
+
+Recorded events are rendered with the same output and summary as a live stream.
+By default events are replayed immediately; use --speed 1x to replay at the
+original pace or a higher multiplier such as --speed 10x to replay faster.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		speedValue, _ := cmd.Flags().GetString("speed")
+		showRaw, _ := cmd.Flags().GetBool("raw")
+
+		speed, err := parseReplaySpeed(speedValue)
+		if err != nil {
+			return err
+		}
+
+		rec, err := readRecording(args[0])
+		if err != nil {
+			return err
+		}
+
+		output := streamOutputFor(showRaw)
+		if output != streamOutputNDJSON {
+			fmt.Printf("⏪ Replaying %s recording", rec.Source)
+			if rec.ServerURL != "" {
+				fmt.Printf(" from %s", rec.ServerURL)
+			}
+			if rec.RecordedAt != nil {
+				fmt.Printf(" (recorded %s)", rec.RecordedAt.Local().Format(time.RFC3339))
+			}
+			fmt.Printf("\n\n")
+		}
+
+		for i, stream := range rec.Streams {
+			if output != streamOutputNDJSON {
+				fmt.Printf("🔄 Stream %d/%d (%s)", i+1, len(rec.Streams), stream.Method)
+				if stream.Params != nil {
+					if text := partsToText(stream.Params.Message.Parts); text != "" {
+						fmt.Printf(": %s", previewText(text, widePreviewLength))
+					}
+				}
+				fmt.Printf("\n\n")
+			}
+
+			summary := consumeStream(replayStream(stream, speed), output)
+			printStreamingResult(output, summary, stream.recordedDuration())
+		}
+
+		return nil
+	},
+}
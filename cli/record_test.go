@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	zap "go.uber.org/zap"
+
+	adk "github.com/inference-gateway/adk/types"
+)
+
+func TestStreamRecorderRoundTrip(t *testing.T) {
+	originalLogger := logger
+	logger = zap.NewNop()
+	defer func() { logger = originalLogger }()
+
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	recorder, err := newStreamRecorder(path, "submit-streaming", "http://localhost:8080")
+	if err != nil {
+		t.Fatalf("newStreamRecorder failed: %v", err)
+	}
+
+	text := "hello"
+	params := adk.MessageSendParams{Message: adk.Message{MessageID: "msg-1", Role: adk.RoleUser, Parts: []adk.Part{{Text: &text}}}}
+
+	in := make(chan adk.JSONRPCSuccessResponse, 2)
+	in <- adk.JSONRPCSuccessResponse{Result: map[string]any{
+		"taskId":    "task-1",
+		"contextId": "ctx-1",
+		"final":     false,
+		"status":    map[string]any{"state": string(adk.TaskStateWorking)},
+	}}
+	in <- adk.JSONRPCSuccessResponse{Result: map[string]any{
+		"taskId":    "task-1",
+		"contextId": "ctx-1",
+		"final":     true,
+		"status":    map[string]any{"state": string(adk.TaskStateCompleted)},
+	}}
+	close(in)
+
+	forwarded := 0
+	for range recorder.tee(recorder.startStream("message/stream", params), in) {
+		forwarded++
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if forwarded != 2 {
+		t.Fatalf("Expected 2 forwarded events, got %d", forwarded)
+	}
+
+	rec, err := readRecording(path)
+	if err != nil {
+		t.Fatalf("readRecording failed: %v", err)
+	}
+	if rec.Source != "submit-streaming" || len(rec.Streams) != 1 {
+		t.Fatalf("Unexpected recording: %+v", rec)
+	}
+
+	stream := rec.Streams[0]
+	if stream.Params == nil || partsToText(stream.Params.Message.Parts) != "hello" {
+		t.Errorf("Expected the recorded request params, got %+v", stream.Params)
+	}
+	if len(stream.Events) != 2 {
+		t.Fatalf("Expected 2 recorded events, got %d", len(stream.Events))
+	}
+
+	summary := consumeStream(replayStream(stream, 0), streamOutputNDJSON)
+	if summary.TaskID != "task-1" || summary.ContextID != "ctx-1" {
+		t.Errorf("Expected replayed task and context IDs, got %q and %q", summary.TaskID, summary.ContextID)
+	}
+	if summary.FinalStatus != string(adk.TaskStateCompleted) || summary.StatusUpdates != 2 {
+		t.Errorf("Unexpected replay summary: %+v", summary)
+	}
+}
+
+func TestReadRecording_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "Empty", content: ""},
+		{name: "Missing header", content: `{"type":"stream","stream":1,"offset_ms":0}` + "\n"},
+		{name: "Unsupported version", content: `{"type":"header","version":99,"offset_ms":0}` + "\n"},
+		{name: "Unknown stream", content: `{"type":"header","version":1,"offset_ms":0}` + "\n" + `{"type":"event","stream":2,"offset_ms":5,"response":{"jsonrpc":"2.0","id":1,"result":{}}}` + "\n"},
+		{name: "Not JSON", content: "not json\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "recording.jsonl")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatalf("failed to write recording: %v", err)
+			}
+			if _, err := readRecording(path); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestParseReplaySpeed(t *testing.T) {
+	tests := []struct {
+		value       string
+		expected    float64
+		expectError bool
+	}{
+		{value: "", expected: 0},
+		{value: "1x", expected: 1},
+		{value: "10X", expected: 10},
+		{value: "0.5", expected: 0.5},
+		{value: "0x", expectError: true},
+		{value: "fast", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			speed, err := parseReplaySpeed(tt.value)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected an error for %q", tt.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseReplaySpeed failed: %v", err)
+			}
+			if speed != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, speed)
+			}
+		})
+	}
+}
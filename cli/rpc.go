@@ -0,0 +1,236 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	uuid "github.com/google/uuid"
+	cobra "github.com/spf13/cobra"
+	viper "github.com/spf13/viper"
+	zap "go.uber.org/zap"
+)
+
+// rpcParams reads the params of a raw JSON-RPC call: inline JSON, @file, or - for stdin.
+// Params must be a JSON object or array.
+func rpcParams(value string, stdin io.Reader) (json.RawMessage, error) {
+	payload := []byte(value)
+	source := "params"
+
+	switch {
+	case value == "-":
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read params from stdin: %w", err)
+		}
+		payload, source = data, "stdin"
+	case strings.HasPrefix(value, "@"):
+		data, err := os.ReadFile(value[1:])
+		if err != nil {
+			return nil, fmt.Errorf("failed to read params file: %w", err)
+		}
+		payload, source = data, value[1:]
+	}
+
+	payload = bytes.TrimSpace(payload)
+	if !json.Valid(payload) {
+		return nil, fmt.Errorf("%s must be valid JSON", source)
+	}
+	if payload[0] != '{' && payload[0] != '[' {
+		return nil, fmt.Errorf("%s must be a JSON object or array", source)
+	}
+	return payload, nil
+}
+
+// rpcRequestID returns the ID of a raw JSON-RPC call: a number when the value is
+// an integer, a string otherwise, and a generated UUID when it is empty
+func rpcRequestID(value string) any {
+	if value == "" {
+		return uuid.NewString()
+	}
+	if id, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return id
+	}
+	return value
+}
+
+// sendRawRPC posts a JSON-RPC request body to the server and passes every message
+// of the reply to handle: the single response of a JSON reply, or each event of
+// an SSE stream as it arrives
+func sendRawRPC(ctx context.Context, httpClient *http.Client, serverURL string, body []byte, handle func(json.RawMessage) error) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a2aEndpointURL(serverURL), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		return readSSEData(resp.Body, handle)
+	}
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize+1))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if len(respBody) > maxResponseBodySize {
+		return fmt.Errorf("HTTP %d response exceeds the limit of %d MiB", resp.StatusCode, maxResponseBodySize>>20)
+	}
+	respBody = bytes.TrimSpace(respBody)
+	if !json.Valid(respBody) {
+		return fmt.Errorf("HTTP %d response is not JSON-RPC: %s", resp.StatusCode, previewText(string(respBody), widePreviewLength))
+	}
+	return handle(respBody)
+}
+
+// readSSEData passes the data of every event of an SSE stream to handle. Data
+// spread over several lines of one event is joined with newlines.
+func readSSEData(body io.Reader, handle func(json.RawMessage) error) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), maxResponseBodySize)
+
+	var data []string
+	dispatch := func() error {
+		if len(data) == 0 {
+			return nil
+		}
+		payload := strings.Join(data, "\n")
+		data = nil
+		if payload == "[DONE]" {
+			return nil
+		}
+		return handle(json.RawMessage(payload))
+	}
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			if err := dispatch(); err != nil {
+				return err
+			}
+			continue
+		}
+		if value, ok := strings.CutPrefix(line, "data:"); ok {
+			data = append(data, strings.TrimPrefix(value, " "))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read event stream: %w", err)
+	}
+	return dispatch()
+}
+
+// printRPCMessage prints a response or stream event of a raw JSON-RPC call.
+// Messages after the first are separated as YAML documents or JSON values.
+func printRPCMessage(seq int, message json.RawMessage) error {
+	format, err := getOutputFormat()
+	if err != nil {
+		return err
+	}
+
+	var value any = message
+	switch {
+	case !json.Valid(message):
+		value = string(message)
+	case format == OutputFormatYAML:
+		decoded, err := toJSONValue(message)
+		if err != nil {
+			return err
+		}
+		value = nativeNumbers(decoded)
+	}
+
+	if seq > 1 && format == OutputFormatYAML {
+		fmt.Println("---")
+	}
+	if err := printFormatted(value); err != nil {
+		return err
+	}
+	if format == OutputFormatJSON {
+		fmt.Println()
+	}
+	return nil
+}
+
+var rpcCmd = &cobra.Command{
+	Use:   "rpc [method] [params]",
+	Short: "Send a raw JSON-RPC request to the A2A server",
+	Long: `Sends an arbitrary JSON-RPC 2.0 request to the server with the configured
+authentication and TLS settings, and prints the full response or error object.
+Use it for extension methods or spec methods the other commands do not cover.
+
+Params are given as inline JSON, as @file, or as - to read them from stdin, and
+must be a JSON object or array. When the server answers with an SSE stream,
+every event is printed as it arrives. The command fails when the server returns
+a JSON-RPC error.`,
+	Example: `  a2a rpc tasks/get '{"id":"7f3c..."}'
+  a2a rpc message/stream @request.json -o ndjson
+  echo '{"id":"7f3c..."}' | a2a rpc tasks/cancel -
+  a2a rpc agent/getAuthenticatedExtendedCard`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := rejectTableOutput(); err != nil {
+			return err
+		}
+
+		ensureA2AClient()
+
+		request := jsonRPCRequest{JSONRPC: "2.0", Method: args[0]}
+		idFlag, _ := cmd.Flags().GetString("id")
+		request.ID = rpcRequestID(idFlag)
+
+		if len(args) == 2 {
+			params, err := rpcParams(args[1], cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+			request.Params = params
+		}
+
+		body, err := json.Marshal(request)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		logger.Debug("Sending raw JSON-RPC request", zap.String("method", request.Method), zap.Any("id", request.ID))
+
+		var (
+			messages int
+			rpcErr   *jsonRPCResponse
+		)
+		err = sendRawRPC(context.Background(), a2aHTTPClient, viper.GetString("server-url"), body, func(message json.RawMessage) error {
+			messages++
+			var decoded jsonRPCResponse
+			if json.Unmarshal(message, &decoded) == nil && decoded.Error != nil && rpcErr == nil {
+				rpcErr = &decoded
+			}
+			return printRPCMessage(messages, message)
+		})
+		if err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+
+		if rpcErr != nil {
+			cmd.SilenceUsage = true
+			err := &a2aError{Method: request.Method, Code: rpcErr.Error.Code, Message: rpcErr.Error.Message, printed: true}
+			if rpcErr.Error.Data != nil {
+				err.Data = *rpcErr.Error.Data
+			}
+			return err
+		}
+		return nil
+	},
+}
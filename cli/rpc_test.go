@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRPCParams(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "params.json")
+	if err := os.WriteFile(path, []byte(`{"id":"from-file"}`), 0o600); err != nil {
+		t.Fatalf("failed to write params: %v", err)
+	}
+
+	valid := map[string]struct {
+		value    string
+		stdin    string
+		expected string
+	}{
+		"Inline": {value: ` {"id":"t1"} `, expected: `{"id":"t1"}`},
+		"Array":  {value: `["a",1]`, expected: `["a",1]`},
+		"File":   {value: "@" + path, expected: `{"id":"from-file"}`},
+		"Stdin":  {value: "-", stdin: "{\"id\":\"piped\"}\n", expected: `{"id":"piped"}`},
+	}
+	for name, tt := range valid {
+		t.Run(name, func(t *testing.T) {
+			params, err := rpcParams(tt.value, strings.NewReader(tt.stdin))
+			if err != nil {
+				t.Fatalf("rpcParams failed: %v", err)
+			}
+			if string(params) != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, params)
+			}
+		})
+	}
+
+	invalid := map[string]string{
+		"Not JSON":     `id=t1`,
+		"Scalar":       `"t1"`,
+		"Missing file": "@" + filepath.Join(t.TempDir(), "missing.json"),
+		"Empty stdin":  "-",
+	}
+	for name, value := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if _, err := rpcParams(value, strings.NewReader("")); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestRPCRequestID(t *testing.T) {
+	if id := rpcRequestID("42"); id != int64(42) {
+		t.Errorf("Expected a numeric ID, got %#v", id)
+	}
+	if id := rpcRequestID("req-1"); id != "req-1" {
+		t.Errorf("Expected a string ID, got %#v", id)
+	}
+	if id, ok := rpcRequestID("").(string); !ok || len(id) != 36 {
+		t.Errorf("Expected a generated UUID, got %#v", id)
+	}
+}
+
+func TestSendRawRPC(t *testing.T) {
+	serverURL := newTestUpstream(t, testMockScenario)
+	ctx := context.Background()
+
+	collect := func(body string) ([]jsonRPCResponse, error) {
+		var messages []jsonRPCResponse
+		err := sendRawRPC(ctx, http.DefaultClient, serverURL, []byte(body), func(message json.RawMessage) error {
+			var decoded jsonRPCResponse
+			if err := json.Unmarshal(message, &decoded); err != nil {
+				t.Fatalf("Expected a JSON-RPC message, got %s", message)
+			}
+			messages = append(messages, decoded)
+			return nil
+		})
+		return messages, err
+	}
+
+	messages, err := collect(`{"jsonrpc":"2.0","id":"x1","method":"extension/unknown"}`)
+	if err != nil || len(messages) != 1 || messages[0].Error == nil || messages[0].Error.Code != -32601 || messages[0].ID != "x1" {
+		t.Errorf("Expected the method not found error, got %+v (%v)", messages, err)
+	}
+
+	messages, err = collect(`{"jsonrpc":"2.0","id":2,"method":"message/stream","params":{"message":{"messageId":"m1","role":"user","parts":[{"kind":"text","text":"weather"}]}}}`)
+	if err != nil || len(messages) != 4 {
+		t.Fatalf("Expected the four stream events, got %d (%v)", len(messages), err)
+	}
+	if !strings.Contains(string(messages[3].Result), `"final":true`) {
+		t.Errorf("Expected the final status update last, got %s", messages[3].Result)
+	}
+
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "upstream unavailable", http.StatusBadGateway)
+	}))
+	defer gateway.Close()
+	err = sendRawRPC(ctx, http.DefaultClient, gateway.URL, []byte(`{}`), func(json.RawMessage) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "HTTP 502") {
+		t.Errorf("Expected the HTTP error to be reported, got %v", err)
+	}
+
+	large := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"` + strings.Repeat("x", maxResponseBodySize) + `"}`))
+	}))
+	defer large.Close()
+	err = sendRawRPC(ctx, http.DefaultClient, large.URL, []byte(`{}`), func(json.RawMessage) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "exceeds the limit of 10 MiB") {
+		t.Errorf("Expected the oversized response to be reported, got %v", err)
+	}
+}
+
+func TestReadSSEData(t *testing.T) {
+	stream := ": comment\r\ndata: {\"a\":\r\ndata: 1}\r\n\r\nevent: message\ndata: [DONE]\n\ndata: {\"b\":2}"
+
+	var payloads []string
+	if err := readSSEData(strings.NewReader(stream), func(message json.RawMessage) error {
+		payloads = append(payloads, string(message))
+		return nil
+	}); err != nil {
+		t.Fatalf("readSSEData failed: %v", err)
+	}
+	if strings.Join(payloads, "|") != "{\"a\":\n1}|{\"b\":2}" {
+		t.Errorf("Unexpected payloads: %q", payloads)
+	}
+}
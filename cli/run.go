@@ -0,0 +1,512 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	uuid "github.com/google/uuid"
+	cobra "github.com/spf13/cobra"
+	viper "github.com/spf13/viper"
+	yaml "gopkg.in/yaml.v3"
+
+	client "github.com/inference-gateway/adk/client"
+	adk "github.com/inference-gateway/adk/types"
+)
+
+// Turn outcomes of a scenario run
+const (
+	turnPassed  = "pass"
+	turnFailed  = "fail"
+	turnSkipped = "skip"
+)
+
+// defaultTurnTimeout bounds a single turn when the scenario does not set a timeout
+const defaultTurnTimeout = 60 * time.Second
+
+// runTaskPollInterval is how often tasks/get is polled while waiting for a turn to finish
+var runTaskPollInterval = 500 * time.Millisecond
+
+// runScenario is a scripted conversation with the agent
+type runScenario struct {
+	Name      string        `yaml:"name"`
+	ContextID string        `yaml:"context_id"`
+	Stream    bool          `yaml:"stream"`
+	Timeout   time.Duration `yaml:"timeout"`
+	Turns     []runTurn     `yaml:"turns"`
+
+	dir  string
+	file string
+}
+
+// runTurn is a single user message of a scenario and the assertions on the agent's reply
+type runTurn struct {
+	Name   string         `yaml:"name"`
+	Text   string         `yaml:"text"`
+	Files  []runFile      `yaml:"files"`
+	Data   map[string]any `yaml:"data"`
+	Expect runExpect      `yaml:"expect"`
+}
+
+// runFile attaches a local file or a file URI to a turn
+type runFile struct {
+	Path      string `yaml:"path"`
+	URI       string `yaml:"uri"`
+	MediaType string `yaml:"media_type"`
+}
+
+// runExpect lists the assertions evaluated once a turn reaches a final or input-required state
+type runExpect struct {
+	State      string        `yaml:"state"`
+	Contains   []string      `yaml:"contains"`
+	Matches    string        `yaml:"matches"`
+	Artifacts  *int          `yaml:"artifacts"`
+	MaxLatency time.Duration `yaml:"max_latency"`
+
+	state   adk.TaskState
+	pattern *regexp.Regexp
+}
+
+// turnResult is the outcome of a single turn
+type turnResult struct {
+	Turn      int           `json:"turn" yaml:"turn"`
+	Name      string        `json:"name,omitempty" yaml:"name,omitempty"`
+	Status    string        `json:"status" yaml:"status"`
+	TaskID    string        `json:"task_id,omitempty" yaml:"task_id,omitempty"`
+	State     string        `json:"state,omitempty" yaml:"state,omitempty"`
+	Reply     string        `json:"reply,omitempty" yaml:"reply,omitempty"`
+	Artifacts int           `json:"artifacts" yaml:"artifacts"`
+	Latency   time.Duration `json:"-" yaml:"-"`
+	LatencyMs int64         `json:"latency_ms" yaml:"latency_ms"`
+	Snapshot  string        `json:"snapshot,omitempty" yaml:"snapshot,omitempty"`
+	Failures  []string      `json:"failures,omitempty" yaml:"failures,omitempty"`
+}
+
+// runReport is the outcome of a scenario run
+type runReport struct {
+	Scenario  string       `json:"scenario,omitempty" yaml:"scenario,omitempty"`
+	ServerURL string       `json:"server_url" yaml:"server_url"`
+	ContextID string       `json:"context_id,omitempty" yaml:"context_id,omitempty"`
+	Passed    int          `json:"passed" yaml:"passed"`
+	Failed    int          `json:"failed" yaml:"failed"`
+	Skipped   int          `json:"skipped" yaml:"skipped"`
+	Turns     []turnResult `json:"turns" yaml:"turns"`
+}
+
+// loadRunScenario reads and validates a scenario file
+func loadRunScenario(path string) (*runScenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario: %w", err)
+	}
+
+	scenario := &runScenario{
+		dir:  filepath.Dir(path),
+		file: strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+	}
+	if err := yaml.Unmarshal(data, scenario); err != nil {
+		return nil, fmt.Errorf("failed to parse scenario: %w", err)
+	}
+
+	if err := scenario.compile(); err != nil {
+		return nil, err
+	}
+	return scenario, nil
+}
+
+// compile validates the scenario and resolves expected states and patterns
+func (s *runScenario) compile() error {
+	if len(s.Turns) == 0 {
+		return fmt.Errorf("scenario has no turns")
+	}
+	if s.Timeout == 0 {
+		s.Timeout = defaultTurnTimeout
+	}
+
+	for i := range s.Turns {
+		turn := &s.Turns[i]
+		if turn.Text == "" && len(turn.Files) == 0 && len(turn.Data) == 0 {
+			return fmt.Errorf("turns[%d]: text, files or data is required", i)
+		}
+
+		for j, file := range turn.Files {
+			if (file.Path == "") == (file.URI == "") {
+				return fmt.Errorf("turns[%d].files[%d]: exactly one of path or uri is required", i, j)
+			}
+		}
+
+		if turn.Expect.State != "" {
+			state := parseTaskState(turn.Expect.State)
+			if !state.Valid() || state == adk.TaskStateUnspecified {
+				return fmt.Errorf("turns[%d]: unknown expected state %q", i, turn.Expect.State)
+			}
+			turn.Expect.state = state
+		}
+
+		if turn.Expect.Matches != "" {
+			pattern, err := regexp.Compile(turn.Expect.Matches)
+			if err != nil {
+				return fmt.Errorf("turns[%d]: invalid matches pattern: %w", i, err)
+			}
+			turn.Expect.pattern = pattern
+		}
+	}
+
+	return nil
+}
+
+// params builds the message of a turn. Relative file paths are resolved against
+// the directory of the scenario file.
+func (s *runScenario) params(turn runTurn, contextID, taskID string) (adk.MessageSendParams, error) {
+	params := buildMessageSendParams(uuid.NewString(), turn.Text, contextID, taskID)
+	if turn.Text == "" {
+		params.Message.Parts = nil
+	}
+
+	for _, file := range turn.Files {
+		var part adk.Part
+		var err error
+		if file.URI != "" {
+			part, err = filePartFromURI(file.URI, file.MediaType)
+		} else {
+			filePath := file.Path
+			if !filepath.IsAbs(filePath) {
+				filePath = filepath.Join(s.dir, filePath)
+			}
+			part, err = filePartFromPath(filePath, file.MediaType, defaultMaxFileSizeMB<<20)
+		}
+		if err != nil {
+			return params, err
+		}
+		params.Message.Parts = append(params.Message.Parts, part)
+	}
+
+	if len(turn.Data) > 0 {
+		params.Message.Parts = append(params.Message.Parts, dataPartFrom(turn.Data))
+	}
+
+	return params, nil
+}
+
+// runScenarioTurns plays the turns of a scenario in order within one context.
+// Like the interactive chat, a turn continues the previous task when the agent
+// asked for more input. Once a turn cannot be completed the remaining turns are
+// skipped, since they depend on the conversation so far. With a snapshot
+// directory the task of every completed turn is also compared to its golden file.
+func runScenarioTurns(ctx context.Context, a2a client.A2AClient, scenario *runScenario, snapshots snapshotOptions) runReport {
+	contextID := scenario.ContextID
+	if contextID == "" {
+		contextID = uuid.NewString()
+	}
+
+	report := runReport{
+		Scenario:  scenario.Name,
+		ServerURL: viper.GetString("server-url"),
+		ContextID: contextID,
+		Turns:     []turnResult{},
+	}
+
+	var lastTask adk.Task
+	aborted := false
+	for i, turn := range scenario.Turns {
+		result := turnResult{Turn: i + 1, Name: turn.Name}
+
+		if aborted {
+			result.Status = turnSkipped
+			report.Skipped++
+			report.Turns = append(report.Turns, result)
+			continue
+		}
+
+		taskID := ""
+		if lastTask.ID != "" && lastTask.Status.State == adk.TaskStateInputRequired {
+			taskID = lastTask.ID
+		}
+
+		task, err := scenario.playTurn(ctx, a2a, turn, contextID, taskID, &result)
+		if err != nil {
+			result.Failures = []string{err.Error()}
+			aborted = true
+		} else {
+			lastTask = task
+			if task.ContextID != "" {
+				contextID = task.ContextID
+			}
+			result.Failures = turn.Expect.evaluate(result)
+
+			if snapshots.Dir != "" {
+				outcome, diffs, err := snapshots.check(fmt.Sprintf("%s/turn-%d", scenario.file, i+1), task)
+				if err != nil {
+					diffs = []string{err.Error()}
+				}
+				result.Snapshot = outcome
+				for _, diff := range diffs {
+					result.Failures = append(result.Failures, "snapshot: "+diff)
+				}
+			}
+		}
+
+		if len(result.Failures) > 0 {
+			result.Status = turnFailed
+			report.Failed++
+		} else {
+			result.Status = turnPassed
+			report.Passed++
+		}
+		report.Turns = append(report.Turns, result)
+	}
+
+	return report
+}
+
+// playTurn sends the message of a turn and waits until its task reaches a final
+// or input-required state, filling in the observed reply on result
+func (s *runScenario) playTurn(ctx context.Context, a2a client.A2AClient, turn runTurn, contextID, taskID string, result *turnResult) (adk.Task, error) {
+	params, err := s.params(turn, contextID, taskID)
+	if err != nil {
+		return adk.Task{}, err
+	}
+
+	turnCtx, cancel := context.WithTimeout(ctx, s.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	var task adk.Task
+	if s.Stream {
+		task, err = streamTurn(turnCtx, a2a, params)
+	} else {
+		task, err = sendTurn(turnCtx, a2a, params)
+	}
+	if err == nil {
+		task, err = awaitTurnTask(turnCtx, a2a, task)
+	}
+	result.Latency = time.Since(start)
+	result.LatencyMs = result.Latency.Milliseconds()
+	if err != nil {
+		return adk.Task{}, err
+	}
+
+	result.TaskID = task.ID
+	result.State = humanState(task.Status.State)
+	result.Reply = taskReplyText(task)
+	result.Artifacts = len(task.Artifacts)
+	return task, nil
+}
+
+// sendTurn sends a turn with message/send
+func sendTurn(ctx context.Context, a2a client.A2AClient, params adk.MessageSendParams) (adk.Task, error) {
+	resp, err := a2a.SendTask(ctx, params)
+	if err != nil {
+		return adk.Task{}, fmt.Errorf("message/send failed: %w", err)
+	}
+
+	task, err := taskFromResult(resp.Result)
+	if err != nil {
+		return adk.Task{}, err
+	}
+	if task.ID == "" {
+		return adk.Task{}, fmt.Errorf("message/send did not return a task")
+	}
+	return task, nil
+}
+
+// streamTurn sends a turn with message/stream, reads the stream to the end and
+// fetches the resulting task so that streamed and sent turns are asserted alike
+func streamTurn(ctx context.Context, a2a client.A2AClient, params adk.MessageSendParams) (adk.Task, error) {
+	events, err := a2a.SendTaskStreaming(ctx, params)
+	if err != nil {
+		return adk.Task{}, fmt.Errorf("message/stream failed: %w", err)
+	}
+
+	var summary streamingSummary
+	for resp := range events {
+		if resp.Result == nil {
+			return adk.Task{}, fmt.Errorf("stream returned an error event")
+		}
+		eventJSON, kind, err := classifyStreamEvent(resp.Result)
+		if err != nil {
+			return adk.Task{}, err
+		}
+		summary.record(kind, eventJSON)
+	}
+
+	if ctx.Err() != nil {
+		return adk.Task{}, fmt.Errorf("stream did not finish in time: %w", ctx.Err())
+	}
+	if summary.TaskID == "" {
+		return adk.Task{}, fmt.Errorf("stream ended without identifying a task")
+	}
+
+	resp, err := a2a.GetTask(ctx, adk.TaskQueryParams{ID: summary.TaskID})
+	if err != nil {
+		return adk.Task{}, fmt.Errorf("tasks/get failed: %w", err)
+	}
+	return taskFromResult(resp.Result)
+}
+
+// awaitTurnTask polls tasks/get until the task is final or waiting for input
+func awaitTurnTask(ctx context.Context, a2a client.A2AClient, task adk.Task) (adk.Task, error) {
+	for !isTerminalState(task.Status.State) && task.Status.State != adk.TaskStateAuthRequired {
+		if !sleepContext(ctx, runTaskPollInterval) {
+			return task, fmt.Errorf("task %s still %s when the turn timed out", shortID(task.ID), humanState(task.Status.State))
+		}
+
+		resp, err := a2a.GetTask(ctx, adk.TaskQueryParams{ID: task.ID})
+		if err != nil {
+			return task, fmt.Errorf("tasks/get failed: %w", err)
+		}
+		if task, err = taskFromResult(resp.Result); err != nil {
+			return task, err
+		}
+	}
+	return task, nil
+}
+
+// taskReplyText returns the agent's reply to the latest message, followed by the text of its artifacts
+func taskReplyText(task adk.Task) string {
+	var texts []string
+
+	reply := ""
+	if task.Status.Message != nil {
+		reply = partsToText(task.Status.Message.Parts)
+	}
+	if reply == "" {
+		reply = latestAgentText(task.History)
+	}
+	if reply != "" {
+		texts = append(texts, reply)
+	}
+
+	for _, artifact := range task.Artifacts {
+		if text := partsToText(artifact.Parts); text != "" {
+			texts = append(texts, text)
+		}
+	}
+
+	return strings.Join(texts, "\n")
+}
+
+// evaluate checks the observed outcome of a turn and returns the failed assertions
+func (e runExpect) evaluate(result turnResult) []string {
+	var failures []string
+
+	if e.State != "" && result.State != humanState(e.state) {
+		failures = append(failures, fmt.Sprintf("expected state %s, got %s", humanState(e.state), result.State))
+	}
+	for _, substring := range e.Contains {
+		if !strings.Contains(result.Reply, substring) {
+			failures = append(failures, fmt.Sprintf("expected reply to contain %q", substring))
+		}
+	}
+	if e.pattern != nil && !e.pattern.MatchString(result.Reply) {
+		failures = append(failures, fmt.Sprintf("expected reply to match %q", e.Matches))
+	}
+	if e.Artifacts != nil && result.Artifacts != *e.Artifacts {
+		failures = append(failures, fmt.Sprintf("expected %d artifact(s), got %d", *e.Artifacts, result.Artifacts))
+	}
+	if e.MaxLatency > 0 && result.Latency > e.MaxLatency {
+		failures = append(failures, fmt.Sprintf("expected a reply within %s, took %s", e.MaxLatency, result.Latency.Round(time.Millisecond)))
+	}
+
+	return failures
+}
+
+// runTable lists the turns of a scenario run
+func runTable(report runReport, wide bool) tableView {
+	view := tableView{Headers: []string{"TURN", "STATUS", "STATE", "LATENCY", "MESSAGE"}}
+	if wide {
+		view.Headers = append(view.Headers, "TASK ID", "ARTIFACTS", "SNAPSHOT")
+	}
+
+	previewLength := tablePreviewLength
+	if wide {
+		previewLength = widePreviewLength
+	}
+
+	for _, result := range report.Turns {
+		turn := strconv.Itoa(result.Turn)
+		if result.Name != "" {
+			turn += " " + result.Name
+		}
+
+		message := result.Reply
+		if len(result.Failures) > 0 {
+			message = strings.Join(result.Failures, "; ")
+		}
+
+		latency := ""
+		if result.Status != turnSkipped {
+			latency = result.Latency.Round(time.Millisecond).String()
+		}
+
+		row := []string{turn, strings.ToUpper(result.Status), result.State, latency, previewText(message, previewLength)}
+		if wide {
+			row = append(row, result.TaskID, strconv.Itoa(result.Artifacts), result.Snapshot)
+		}
+		view.Rows = append(view.Rows, row)
+	}
+
+	return view
+}
+
+var runCmd = &cobra.Command{
+	Use:   "run [scenario.yaml]",
+	Short: "Run a scripted multi-turn conversation against the A2A server",
+	Long: `Plays the user turns of a YAML scenario against the A2A server within one
+context and checks each reply against the turn's assertions: final state, text the
+reply must contain or match, number of artifacts and maximum latency.
+
+Turns may attach files and structured data. A turn automatically continues the
+previous task when the agent asked for more input. With --snapshot-dir the task of
+every turn is also compared to a golden file with IDs and timestamps masked. The
+report is printed in the configured output format (use -o table for a summary) and
+the command exits with a non-zero status when an assertion fails, so scenarios can
+run as regression tests in CI.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		scenario, err := loadRunScenario(args[0])
+		if err != nil {
+			return err
+		}
+
+		if cmd.Flags().Changed("stream") {
+			scenario.Stream, _ = cmd.Flags().GetBool("stream")
+		}
+		if cmd.Flags().Changed("turn-timeout") {
+			scenario.Timeout, _ = cmd.Flags().GetDuration("turn-timeout")
+		}
+		if contextID, _ := cmd.Flags().GetString("context-id"); contextID != "" {
+			scenario.ContextID = contextID
+		}
+
+		snapshots, err := snapshotOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		ensureA2AClient()
+		// A turn may wait on a single request for its whole timeout
+		if scenario.Timeout > viper.GetDuration("timeout") {
+			a2aClient.SetTimeout(scenario.Timeout)
+		}
+
+		report := runScenarioTurns(context.Background(), a2aClient, scenario, snapshots)
+
+		if err := printFormattedTable(report, func(wide bool) tableView {
+			return runTable(report, wide)
+		}); err != nil {
+			return err
+		}
+
+		if report.Failed > 0 {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("%d of %d turn(s) failed", report.Failed, len(report.Turns))
+		}
+		return nil
+	},
+}
@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+const testRunMockScenario = `
+responses:
+  - match: "(?i)book"
+    steps:
+      - state: input-required
+        text: Which date?
+  - match: "(?i)tomorrow"
+    steps:
+      - state: working
+      - artifact:
+          name: booking
+          text: "Table for 2, tomorrow 19:00"
+      - state: completed
+        text: "Booked for {{input}}"
+`
+
+func writeRunScenario(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "menu.txt"), []byte("soup"), 0o600); err != nil {
+		t.Fatalf("failed to write attachment: %v", err)
+	}
+	path := filepath.Join(dir, "run.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write scenario: %v", err)
+	}
+	return path
+}
+
+func TestLoadRunScenario(t *testing.T) {
+	scenario, err := loadRunScenario(writeRunScenario(t, "turns:\n  - text: hi\n    expect:\n      state: completed\n      max_latency: 2s\n"))
+	if err != nil {
+		t.Fatalf("loadRunScenario failed: %v", err)
+	}
+	if scenario.Timeout != defaultTurnTimeout || scenario.Turns[0].Expect.MaxLatency != 2*time.Second {
+		t.Errorf("Unexpected scenario: %+v", scenario)
+	}
+
+	invalid := map[string]string{
+		"No turns":        "name: empty\n",
+		"Empty turn":      "turns:\n  - name: nothing\n",
+		"Unknown state":   "turns:\n  - text: hi\n    expect:\n      state: sleeping\n",
+		"Invalid pattern": "turns:\n  - text: hi\n    expect:\n      matches: \"(\"\n",
+		"Path and URI":    "turns:\n  - files:\n      - path: a.txt\n        uri: https://example.com/a.txt\n",
+	}
+	for name, content := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if _, err := loadRunScenario(writeRunScenario(t, content)); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestRunScenarioTurns(t *testing.T) {
+	for _, stream := range []bool{false, true} {
+		t.Run(map[bool]string{false: "send", true: "stream"}[stream], func(t *testing.T) {
+			a2a := newTestMockClient(t, testRunMockScenario)
+			scenario, err := loadRunScenario(writeRunScenario(t, `
+turns:
+  - name: ask
+    text: Book a table
+    files:
+      - path: menu.txt
+    data: {party: 2}
+    expect:
+      state: input-required
+      contains: [date]
+  - name: answer
+    text: tomorrow
+    expect:
+      state: completed
+      matches: "Booked for tomorrow"
+      contains: ["19:00"]
+      artifacts: 1
+      max_latency: 5s
+`))
+			if err != nil {
+				t.Fatalf("loadRunScenario failed: %v", err)
+			}
+			scenario.Stream = stream
+
+			report := runScenarioTurns(context.Background(), a2a, scenario, snapshotOptions{})
+			if report.Passed != 2 || report.Failed != 0 {
+				t.Fatalf("Expected both turns to pass, got %+v", report)
+			}
+			if report.Turns[0].TaskID != report.Turns[1].TaskID {
+				t.Errorf("Expected the second turn to continue the input-required task, got %s and %s", report.Turns[0].TaskID, report.Turns[1].TaskID)
+			}
+		})
+	}
+}
+
+func TestRunScenarioTurns_Failures(t *testing.T) {
+	a2a := newTestMockClient(t, "responses:\n  - match: boom\n    error: {code: -32603, message: boom}\n  - steps:\n      - state: completed\n        text: hello\n")
+	scenario, err := loadRunScenario(writeRunScenario(t, `
+turns:
+  - text: hi
+    expect:
+      state: failed
+      contains: [goodbye]
+      artifacts: 1
+  - text: boom
+  - text: never sent
+`))
+	if err != nil {
+		t.Fatalf("loadRunScenario failed: %v", err)
+	}
+
+	report := runScenarioTurns(context.Background(), a2a, scenario, snapshotOptions{})
+	if report.Failed != 2 || report.Skipped != 1 || report.Passed != 0 {
+		t.Fatalf("Unexpected totals: %+v", report)
+	}
+	if failures := report.Turns[0].Failures; len(failures) != 3 {
+		t.Errorf("Expected the state, contains and artifacts assertions to fail, got %v", failures)
+	}
+	if failures := report.Turns[1].Failures; len(failures) != 1 || !strings.Contains(failures[0], "-32603") {
+		t.Errorf("Expected the JSON-RPC error to be reported, got %v", failures)
+	}
+	if report.Turns[2].Status != turnSkipped {
+		t.Errorf("Expected the turn after a failed request to be skipped, got %+v", report.Turns[2])
+	}
+}
+
+func TestRunCmd_TurnTimeout(t *testing.T) {
+	if runCmd.LocalNonPersistentFlags().Lookup("timeout") != nil {
+		t.Fatal("Expected run not to shadow the global --timeout flag")
+	}
+
+	originalClient, originalHTTPClient, originalLogger := a2aClient, a2aHTTPClient, logger
+	defer func() {
+		a2aClient, a2aHTTPClient, logger = originalClient, originalHTTPClient, originalLogger
+		viper.Set("server-url", "")
+		viper.Set("output", "yaml")
+		_ = runCmd.Flags().Set("turn-timeout", defaultTurnTimeout.String())
+		runCmd.Flags().Lookup("turn-timeout").Changed = false
+	}()
+	logger = zap.NewNop()
+	a2aClient = nil
+	viper.Set("server-url", newTestUpstream(t, testRunMockScenario))
+	viper.Set("output", "json")
+
+	path := writeRunScenario(t, "turns:\n  - text: Book a table\n    expect:\n      state: input-required\n")
+	if err := runCmd.Flags().Set("turn-timeout", "2m"); err != nil {
+		t.Fatalf("failed to set --turn-timeout: %v", err)
+	}
+
+	oldStdout := os.Stdout
+	_, w, _ := os.Pipe()
+	os.Stdout = w
+	err := runCmd.RunE(runCmd, []string{path})
+	_ = w.Close()
+	os.Stdout = oldStdout
+
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if a2aHTTPClient.Timeout != 2*time.Minute {
+		t.Errorf("Expected the request timeout to be raised to the turn timeout, got %s", a2aHTTPClient.Timeout)
+	}
+}
@@ -0,0 +1,332 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	cobra "github.com/spf13/cobra"
+)
+
+// Placeholders for values that change between runs
+const (
+	snapshotMaskedID        = "<id>"
+	snapshotMaskedTimestamp = "<timestamp>"
+)
+
+// Snapshot check outcomes
+const (
+	snapshotMatched  = "matched"
+	snapshotWritten  = "written"
+	snapshotUpdated  = "updated"
+	snapshotMismatch = "mismatch"
+)
+
+// maxSnapshotNameLength bounds snapshot file names derived from a message
+const maxSnapshotNameLength = 60
+
+// snapshotIDFields are the task fields whose values are generated by the client or server
+var snapshotIDFields = map[string]bool{
+	"id":               true,
+	"taskId":           true,
+	"contextId":        true,
+	"messageId":        true,
+	"artifactId":       true,
+	"referenceTaskIds": true,
+}
+
+// snapshotTextFields are compared with the configured text similarity instead of exactly
+var snapshotTextFields = map[string]bool{
+	"text": true,
+}
+
+// snapshotNameCleaner matches runs of characters that are not kept in snapshot file names
+var snapshotNameCleaner = regexp.MustCompile(`[^a-z0-9]+`)
+
+// snapshotOptions configures golden-file checks of task results
+type snapshotOptions struct {
+	Dir            string
+	Update         bool
+	TextSimilarity float64
+}
+
+// snapshotOptionsFromFlags reads the snapshot flags of a command. Snapshots are
+// disabled when the returned options have an empty Dir.
+func snapshotOptionsFromFlags(cmd *cobra.Command) (snapshotOptions, error) {
+	dir, _ := cmd.Flags().GetString("snapshot-dir")
+	update, _ := cmd.Flags().GetBool("update-snapshots")
+	similarity, _ := cmd.Flags().GetFloat64("snapshot-text-similarity")
+
+	if update && dir == "" {
+		return snapshotOptions{}, fmt.Errorf("--update-snapshots requires --snapshot-dir")
+	}
+	if similarity < 0 || similarity > 1 {
+		return snapshotOptions{}, fmt.Errorf("--snapshot-text-similarity must be between 0 and 1")
+	}
+
+	return snapshotOptions{Dir: dir, Update: update, TextSimilarity: similarity}, nil
+}
+
+// addSnapshotFlags registers the snapshot flags on a command
+func addSnapshotFlags(cmd *cobra.Command) {
+	cmd.Flags().String("snapshot-dir", "", "Compare task results against golden files in this directory (missing files are written)")
+	cmd.Flags().Bool("update-snapshots", false, "Overwrite the golden files with the current task results")
+	cmd.Flags().Float64("snapshot-text-similarity", 1, "Minimum similarity (0-1) of text parts to their snapshot, 1 requires equal text up to whitespace")
+}
+
+// snapshotName derives a file name from free text such as a message
+func snapshotName(text string) string {
+	name := strings.Trim(snapshotNameCleaner.ReplaceAllString(strings.ToLower(text), "-"), "-")
+	if len(name) > maxSnapshotNameLength {
+		name = strings.TrimRight(name[:maxSnapshotNameLength], "-")
+	}
+	if name == "" {
+		name = "snapshot"
+	}
+	return name
+}
+
+// check compares a task result with its golden file, writing the file when it does
+// not exist yet or when updating. It returns the outcome and, on a mismatch, the
+// differences found.
+func (o snapshotOptions) check(name string, value any) (string, []string, error) {
+	actual, err := normalizeSnapshot(value)
+	if err != nil {
+		return "", nil, err
+	}
+
+	path := filepath.Join(o.Dir, name+".json")
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return snapshotWritten, nil, writeSnapshot(path, actual)
+	case err != nil:
+		return "", nil, fmt.Errorf("failed to read snapshot: %w", err)
+	case o.Update:
+		return snapshotUpdated, nil, writeSnapshot(path, actual)
+	}
+
+	var expected any
+	if err := json.Unmarshal(data, &expected); err != nil {
+		return "", nil, fmt.Errorf("failed to parse snapshot %s: %w", path, err)
+	}
+
+	if diffs := diffSnapshot("", expected, actual, o.TextSimilarity); len(diffs) > 0 {
+		return snapshotMismatch, diffs, nil
+	}
+	return snapshotMatched, nil, nil
+}
+
+// writeSnapshot stores a normalized result as indented JSON
+func writeSnapshot(path string, value any) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// normalizeSnapshot converts a result to plain JSON values and masks IDs and timestamps
+func normalizeSnapshot(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal result: %w", err)
+	}
+
+	var normalized any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal result: %w", err)
+	}
+	return maskSnapshotValue("", normalized), nil
+}
+
+// maskSnapshotValue replaces generated IDs and timestamps below key with placeholders
+func maskSnapshotValue(key string, value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for k, child := range v {
+			v[k] = maskSnapshotValue(k, child)
+		}
+		return v
+	case []any:
+		for i, child := range v {
+			v[i] = maskSnapshotValue(key, child)
+		}
+		return v
+	case string:
+		if snapshotIDFields[key] && v != "" {
+			return snapshotMaskedID
+		}
+		if _, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return snapshotMaskedTimestamp
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// diffSnapshot lists the differences between a golden value and a new result.
+// Text fields only need to reach the given similarity.
+func diffSnapshot(path string, expected, actual any, similarity float64) []string {
+	switch exp := expected.(type) {
+	case map[string]any:
+		act, ok := actual.(map[string]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected an object, got %s", snapshotPath(path), snapshotJSON(actual))}
+		}
+
+		keys := map[string]bool{}
+		for k := range exp {
+			keys[k] = true
+		}
+		for k := range act {
+			keys[k] = true
+		}
+
+		var diffs []string
+		for _, k := range sortedKeys(keys) {
+			child := k
+			if path != "" {
+				child = path + "." + k
+			}
+			expValue, inExpected := exp[k]
+			actValue, inActual := act[k]
+			switch {
+			case !inActual:
+				diffs = append(diffs, fmt.Sprintf("%s: missing, expected %s", child, snapshotJSON(expValue)))
+			case !inExpected:
+				diffs = append(diffs, fmt.Sprintf("%s: unexpected %s", child, snapshotJSON(actValue)))
+			case snapshotTextFields[k]:
+				diffs = append(diffs, diffSnapshotText(child, expValue, actValue, similarity)...)
+			default:
+				diffs = append(diffs, diffSnapshot(child, expValue, actValue, similarity)...)
+			}
+		}
+		return diffs
+	case []any:
+		act, ok := actual.([]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected a list, got %s", snapshotPath(path), snapshotJSON(actual))}
+		}
+		if len(exp) != len(act) {
+			return []string{fmt.Sprintf("%s: expected %d item(s), got %d", snapshotPath(path), len(exp), len(act))}
+		}
+
+		var diffs []string
+		for i := range exp {
+			diffs = append(diffs, diffSnapshot(fmt.Sprintf("%s[%d]", path, i), exp[i], act[i], similarity)...)
+		}
+		return diffs
+	default:
+		if snapshotJSON(expected) != snapshotJSON(actual) {
+			return []string{fmt.Sprintf("%s: expected %s, got %s", snapshotPath(path), snapshotJSON(expected), snapshotJSON(actual))}
+		}
+		return nil
+	}
+}
+
+// diffSnapshotText compares a text field by word similarity, ignoring differences in whitespace
+func diffSnapshotText(path string, expected, actual any, similarity float64) []string {
+	expText, expOK := expected.(string)
+	actText, actOK := actual.(string)
+	if !expOK || !actOK {
+		return diffSnapshot(path, expected, actual, similarity)
+	}
+
+	score := textSimilarity(expText, actText)
+	if score >= similarity {
+		return nil
+	}
+
+	previewLength := widePreviewLength
+	if similarity < 1 {
+		return []string{fmt.Sprintf("%s: text similarity %.2f is below %.2f, expected %q, got %q", path, score, similarity, previewText(expText, previewLength), previewText(actText, previewLength))}
+	}
+	return []string{fmt.Sprintf("%s: expected %q, got %q", path, previewText(expText, previewLength), previewText(actText, previewLength))}
+}
+
+// textSimilarity scores two texts between 0 and 1 by the word-level edit distance
+// relative to the longer text. Texts that only differ in whitespace score 1.
+func textSimilarity(a, b string) float64 {
+	wordsA := strings.Fields(a)
+	wordsB := strings.Fields(b)
+
+	longest := max(len(wordsA), len(wordsB))
+	if longest == 0 {
+		return 1
+	}
+
+	previous := make([]int, len(wordsB)+1)
+	current := make([]int, len(wordsB)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(wordsA); i++ {
+		current[0] = i
+		for j := 1; j <= len(wordsB); j++ {
+			cost := 1
+			if wordsA[i-1] == wordsB[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return 1 - float64(previous[len(wordsB)])/float64(longest)
+}
+
+// snapshotPath names the root of a snapshot in difference messages
+func snapshotPath(path string) string {
+	if path == "" {
+		return "(root)"
+	}
+	return path
+}
+
+// snapshotJSON renders a value compactly for difference messages
+func snapshotJSON(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return previewText(string(data), widePreviewLength)
+}
+
+// printSnapshotResult reports the outcome of a snapshot check on stderr and
+// returns an error listing the differences on a mismatch
+func printSnapshotResult(cmd *cobra.Command, name, outcome string, diffs []string) error {
+	switch outcome {
+	case snapshotWritten:
+		fmt.Fprintf(os.Stderr, "📸 Snapshot %s written\n", name)
+	case snapshotUpdated:
+		fmt.Fprintf(os.Stderr, "📸 Snapshot %s updated\n", name)
+	case snapshotMatched:
+		fmt.Fprintf(os.Stderr, "✅ Snapshot %s matched\n", name)
+	case snapshotMismatch:
+		fmt.Fprintf(os.Stderr, "❌ Snapshot %s does not match:\n", name)
+		for _, diff := range diffs {
+			fmt.Fprintf(os.Stderr, "  - %s\n", diff)
+		}
+		cmd.SilenceUsage = true
+		return fmt.Errorf("snapshot %s does not match, rerun with --update-snapshots to accept the new result", name)
+	}
+	return nil
+}
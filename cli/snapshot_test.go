@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	adk "github.com/inference-gateway/adk/types"
+)
+
+func snapshotTestTask(taskID, reply string) adk.Task {
+	now := time.Now()
+	text := reply
+	return adk.Task{
+		ID:        taskID,
+		ContextID: "ctx-" + taskID,
+		Status: adk.TaskStatus{
+			State:     adk.TaskStateCompleted,
+			Timestamp: &now,
+			Message: &adk.Message{
+				MessageID: "msg-" + taskID,
+				Role:      adk.RoleAgent,
+				Parts:     []adk.Part{{Text: &text}},
+			},
+		},
+	}
+}
+
+func TestSnapshotCheck(t *testing.T) {
+	snapshots := snapshotOptions{Dir: t.TempDir(), TextSimilarity: 1}
+
+	outcome, _, err := snapshots.check("greeting", snapshotTestTask("task-1", "Hello there, how can I help?"))
+	if err != nil || outcome != snapshotWritten {
+		t.Fatalf("Expected the snapshot to be written, got %q (%v)", outcome, err)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(snapshots.Dir, "greeting.json"))
+	if strings.Contains(string(data), "task-1") || !strings.Contains(string(data), `"id": "<id>"`) || !strings.Contains(string(data), `"timestamp": "<timestamp>"`) {
+		t.Errorf("Expected IDs and timestamps to be masked, got:\n%s", data)
+	}
+
+	outcome, diffs, err := snapshots.check("greeting", snapshotTestTask("task-2", "Hello  there, how can I help?"))
+	if err != nil || outcome != snapshotMatched {
+		t.Errorf("Expected new IDs and whitespace changes to match, got %q %v (%v)", outcome, diffs, err)
+	}
+
+	outcome, diffs, _ = snapshots.check("greeting", snapshotTestTask("task-3", "Hello there, how can I assist?"))
+	if outcome != snapshotMismatch || len(diffs) != 1 || !strings.HasPrefix(diffs[0], "status.message.parts[0].text:") {
+		t.Errorf("Expected a text difference, got %q %v", outcome, diffs)
+	}
+
+	snapshots.TextSimilarity = 0.8
+	if outcome, diffs, _ = snapshots.check("greeting", snapshotTestTask("task-3", "Hello there, how can I assist?")); outcome != snapshotMatched {
+		t.Errorf("Expected a similar text to match with fuzzy matching, got %q %v", outcome, diffs)
+	}
+
+	failed := snapshotTestTask("task-4", "Hello there, how can I help?")
+	failed.Status.State = adk.TaskStateFailed
+	if outcome, diffs, _ = snapshots.check("greeting", failed); outcome != snapshotMismatch || !strings.Contains(diffs[0], "status.state") {
+		t.Errorf("Expected non-text fields to be compared exactly, got %q %v", outcome, diffs)
+	}
+
+	snapshots.Update = true
+	if outcome, _, _ = snapshots.check("greeting", failed); outcome != snapshotUpdated {
+		t.Errorf("Expected the snapshot to be updated, got %q", outcome)
+	}
+}
+
+func TestTextSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected float64
+	}{
+		{"the quick brown fox", "the  quick\nbrown fox", 1},
+		{"the quick brown fox", "the quick red fox", 0.75},
+		{"one two", "", 0},
+		{"", "", 1},
+	}
+	for _, tt := range tests {
+		if got := textSimilarity(tt.a, tt.b); got != tt.expected {
+			t.Errorf("textSimilarity(%q, %q) = %v, expected %v", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
+
+func TestSnapshotName(t *testing.T) {
+	if got := snapshotName("What is the weather in Berlin?"); got != "what-is-the-weather-in-berlin" {
+		t.Errorf("Unexpected name: %q", got)
+	}
+	if got := snapshotName(strings.Repeat("long ", 30)); len(got) > maxSnapshotNameLength || strings.HasSuffix(got, "-") {
+		t.Errorf("Expected a truncated name, got %q", got)
+	}
+	if got := snapshotName("???"); got != "snapshot" {
+		t.Errorf("Expected a fallback name, got %q", got)
+	}
+}
+
+func TestRunScenarioTurns_Snapshots(t *testing.T) {
+	a2a := newTestMockClient(t, testRunMockScenario)
+	scenario, err := loadRunScenario(writeRunScenario(t, "turns:\n  - text: Book a table\n  - text: tomorrow\n"))
+	if err != nil {
+		t.Fatalf("loadRunScenario failed: %v", err)
+	}
+	snapshots := snapshotOptions{Dir: t.TempDir(), TextSimilarity: 1}
+
+	report := runScenarioTurns(context.Background(), a2a, scenario, snapshots)
+	if report.Failed != 0 || report.Turns[0].Snapshot != snapshotWritten {
+		t.Fatalf("Expected the snapshots to be written, got %+v", report)
+	}
+	if _, err := os.Stat(filepath.Join(snapshots.Dir, "run", "turn-2.json")); err != nil {
+		t.Errorf("Expected a snapshot per turn: %v", err)
+	}
+
+	report = runScenarioTurns(context.Background(), a2a, scenario, snapshots)
+	if report.Failed != 0 || report.Turns[1].Snapshot != snapshotMatched {
+		t.Errorf("Expected the second run to match, got %+v", report)
+	}
+
+	scenario.Turns[1].Text = "tomorrow evening"
+	report = runScenarioTurns(context.Background(), a2a, scenario, snapshots)
+	if report.Failed != 1 || report.Turns[1].Snapshot != snapshotMismatch || !strings.HasPrefix(report.Turns[1].Failures[0], "snapshot: ") {
+		t.Errorf("Expected the changed reply to fail its snapshot, got %+v", report.Turns[1])
+	}
+}
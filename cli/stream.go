@@ -0,0 +1,332 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	zap "go.uber.org/zap"
+
+	adk "github.com/inference-gateway/adk/types"
+)
+
+// Stream event kinds derived from the shape of a streaming result
+const (
+	eventKindStatusUpdate   = "status-update"
+	eventKindArtifactUpdate = "artifact-update"
+	eventKindTask           = "task"
+)
+
+// streamOutput selects how consumeStream renders events
+type streamOutput int
+
+const (
+	streamOutputText streamOutput = iota
+	streamOutputRaw
+	streamOutputNDJSON
+)
+
+// streamOutputFor resolves the stream rendering from the --raw flag and the output format
+func streamOutputFor(showRaw bool) streamOutput {
+	if format, err := getOutputFormat(); err == nil && format == OutputFormatNDJSON {
+		return streamOutputNDJSON
+	}
+	if showRaw {
+		return streamOutputRaw
+	}
+	return streamOutputText
+}
+
+// ndjsonStreamEvent is a single stream event in NDJSON output
+type ndjsonStreamEvent struct {
+	Type       string          `json:"type"`
+	Seq        int             `json:"seq"`
+	Kind       string          `json:"kind"`
+	ReceivedAt time.Time       `json:"received_at"`
+	Event      json.RawMessage `json:"event"`
+}
+
+// ndjsonStreamSummary is the final line of NDJSON stream output
+type ndjsonStreamSummary struct {
+	Type            string `json:"type"`
+	TaskID          string `json:"task_id"`
+	ContextID       string `json:"context_id"`
+	FinalStatus     string `json:"final_status"`
+	DurationMs      int64  `json:"duration_ms"`
+	TotalEvents     int    `json:"total_events"`
+	StatusUpdates   int    `json:"status_updates"`
+	ArtifactUpdates int    `json:"artifact_updates"`
+}
+
+// streamingSummary aggregates the events received on a task stream
+type streamingSummary struct {
+	TaskID          string
+	ContextID       string
+	FinalStatus     string
+	StatusUpdates   int
+	ArtifactUpdates int
+	TotalEvents     int
+	FinalMessage    *adk.Message
+}
+
+// classifyStreamEvent marshals a streaming result and determines its event kind.
+// The kind is empty when the event shape is not recognized.
+func classifyStreamEvent(result any) ([]byte, string, error) {
+	eventJSON, err := json.Marshal(result)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	var genericEvent map[string]any
+	if err := json.Unmarshal(eventJSON, &genericEvent); err != nil {
+		return nil, "", fmt.Errorf("failed to unmarshal generic event: %w", err)
+	}
+
+	_, hasArtifact := genericEvent["artifact"]
+	_, hasFinal := genericEvent["final"]
+	_, hasID := genericEvent["id"]
+
+	switch {
+	case hasArtifact:
+		return eventJSON, eventKindArtifactUpdate, nil
+	case hasFinal:
+		return eventJSON, eventKindStatusUpdate, nil
+	case hasID:
+		return eventJSON, eventKindTask, nil
+	default:
+		return eventJSON, "", nil
+	}
+}
+
+// record updates the summary with a single classified event
+func (s *streamingSummary) record(eventKind string, eventJSON []byte) {
+	switch eventKind {
+	case eventKindStatusUpdate:
+		s.StatusUpdates++
+		var statusEvent adk.TaskStatusUpdateEvent
+		if err := json.Unmarshal(eventJSON, &statusEvent); err == nil {
+			if s.TaskID == "" {
+				s.TaskID = statusEvent.TaskID
+			}
+			if s.ContextID == "" {
+				s.ContextID = statusEvent.ContextID
+			}
+			s.FinalStatus = string(statusEvent.Status.State)
+			if statusEvent.Status.Message != nil {
+				s.FinalMessage = statusEvent.Status.Message
+			}
+		}
+	case eventKindArtifactUpdate:
+		s.ArtifactUpdates++
+		var artifactEvent adk.TaskArtifactUpdateEvent
+		if err := json.Unmarshal(eventJSON, &artifactEvent); err == nil {
+			if s.TaskID == "" {
+				s.TaskID = artifactEvent.TaskID
+			}
+			if s.ContextID == "" {
+				s.ContextID = artifactEvent.ContextID
+			}
+		}
+	case eventKindTask:
+		var task adk.Task
+		if err := json.Unmarshal(eventJSON, &task); err == nil {
+			if s.TaskID == "" {
+				s.TaskID = task.ID
+			}
+			if s.ContextID == "" {
+				s.ContextID = task.ContextID
+			}
+			s.FinalStatus = string(task.Status.State)
+			if task.Status.Message != nil {
+				s.FinalMessage = task.Status.Message
+			}
+		}
+	}
+}
+
+// consumeStream renders every event received on respChan and returns the
+// accumulated summary once the channel is closed
+func consumeStream(respChan <-chan adk.JSONRPCSuccessResponse, output streamOutput) streamingSummary {
+	var summary streamingSummary
+
+	for resp := range respChan {
+		receivedAt := time.Now()
+		summary.TotalEvents++
+
+		eventJSON, eventKind, err := classifyStreamEvent(resp.Result)
+		if err != nil {
+			logger.Error("Failed to classify event", zap.Error(err))
+			continue
+		}
+
+		summary.record(eventKind, eventJSON)
+
+		switch output {
+		case streamOutputNDJSON:
+			printNDJSONStreamEvent(summary.TotalEvents, eventKind, eventJSON, receivedAt)
+		case streamOutputRaw:
+			printRawStreamEvent(resp)
+		default:
+			printStreamEvent(eventKind, eventJSON)
+		}
+	}
+
+	return summary
+}
+
+// printNDJSONStreamEvent prints a stream event as a single compact JSON line
+func printNDJSONStreamEvent(seq int, eventKind string, eventJSON []byte, receivedAt time.Time) {
+	if eventKind == "" {
+		eventKind = "unknown"
+	}
+	printNDJSONLine(ndjsonStreamEvent{
+		Type:       "event",
+		Seq:        seq,
+		Kind:       eventKind,
+		ReceivedAt: receivedAt.UTC(),
+		Event:      eventJSON,
+	})
+}
+
+// printNDJSONLine prints a value as a single compact JSON line
+func printNDJSONLine(value any) {
+	line, err := json.Marshal(value)
+	if err != nil {
+		logger.Error("Failed to marshal NDJSON line", zap.Error(err))
+		return
+	}
+	fmt.Printf("%s\n", line)
+}
+
+// printRawStreamEvent prints the indented JSON of a streaming result
+func printRawStreamEvent(resp adk.JSONRPCSuccessResponse) {
+	eventJSONFormatted, err := json.MarshalIndent(resp.Result, "", "  ")
+	if err != nil {
+		logger.Error("Failed to marshal event", zap.Error(err))
+		return
+	}
+	fmt.Printf("📡 Raw Event:\n%s\n\n", eventJSONFormatted)
+}
+
+// printStreamEvent prints a human-readable rendering of a classified streaming event
+func printStreamEvent(eventKind string, eventJSON []byte) {
+	switch eventKind {
+	case eventKindStatusUpdate:
+		var statusEvent adk.TaskStatusUpdateEvent
+		if err := json.Unmarshal(eventJSON, &statusEvent); err != nil {
+			logger.Error("Failed to unmarshal status event", zap.Error(err))
+			return
+		}
+
+		fmt.Printf("📊 Status Update: %s", statusEvent.Status.State)
+		if statusEvent.Status.Message != nil {
+			fmt.Printf(" (Message: %s)", statusEvent.Status.Message.MessageID)
+		}
+		if statusEvent.Final {
+			fmt.Printf(" [FINAL]")
+		}
+		fmt.Printf("\n")
+
+		if statusEvent.Status.Message != nil && len(statusEvent.Status.Message.Parts) > 0 {
+			fmt.Printf("\n💬 Agent Response:\n")
+			for _, part := range statusEvent.Status.Message.Parts {
+				if part.Text != nil {
+					fmt.Printf("%s\n", *part.Text)
+				}
+			}
+			fmt.Printf("\n")
+		}
+
+	case eventKindArtifactUpdate:
+		var artifactEvent adk.TaskArtifactUpdateEvent
+		if err := json.Unmarshal(eventJSON, &artifactEvent); err != nil {
+			logger.Error("Failed to unmarshal artifact event", zap.Error(err))
+			return
+		}
+
+		fmt.Printf("📄 Artifact Update:\n")
+		fmt.Printf("  Artifact ID: %s\n", artifactEvent.Artifact.ArtifactID)
+		if artifactEvent.Artifact.Name != nil {
+			fmt.Printf("  Name: %s\n", *artifactEvent.Artifact.Name)
+		}
+		if artifactEvent.Artifact.Description != nil {
+			fmt.Printf("  Description: %s\n", *artifactEvent.Artifact.Description)
+		}
+		if len(artifactEvent.Artifact.Parts) > 0 {
+			fmt.Printf("  Parts:\n")
+			for i, part := range artifactEvent.Artifact.Parts {
+				switch {
+				case part.Text != nil:
+					fmt.Printf("    Part %d: [text] %s\n", i+1, *part.Text)
+				case part.File != nil:
+					fmt.Printf("    Part %d: [file] %s\n", i+1, part.File.Name)
+				case part.Data != nil:
+					fmt.Printf("    Part %d: [data]\n", i+1)
+				}
+			}
+		}
+		if artifactEvent.LastChunk != nil && *artifactEvent.LastChunk {
+			fmt.Printf("  [LAST CHUNK]\n")
+		}
+
+	case eventKindTask:
+		var task adk.Task
+		if err := json.Unmarshal(eventJSON, &task); err != nil {
+			logger.Error("Failed to unmarshal task snapshot", zap.Error(err))
+			return
+		}
+
+		fmt.Printf("📦 Task Snapshot: %s [%s]\n", task.ID, task.Status.State)
+		if task.Status.Message != nil && len(task.Status.Message.Parts) > 0 {
+			fmt.Printf("\n💬 Agent Response:\n")
+			for _, part := range task.Status.Message.Parts {
+				if part.Text != nil {
+					fmt.Printf("%s\n", *part.Text)
+				}
+			}
+			fmt.Printf("\n")
+		}
+
+	default:
+		fmt.Printf("🔔 Unknown Event\n")
+	}
+	fmt.Printf("\n")
+}
+
+// printStreamingSummary prints the end-of-stream summary
+func printStreamingSummary(summary streamingSummary, duration time.Duration) {
+	fmt.Printf("✅ Streaming completed!\n\n")
+	fmt.Printf("📋 Streaming Summary:\n")
+	fmt.Printf("  Task ID: %s\n", summary.TaskID)
+	fmt.Printf("  Context ID: %s\n", summary.ContextID)
+	fmt.Printf("  Final Status: %s\n", summary.FinalStatus)
+	fmt.Printf("  Duration: %s\n", duration.Round(time.Millisecond))
+	fmt.Printf("  Total Events: %d\n", summary.TotalEvents)
+	fmt.Printf("    Status Updates: %d\n", summary.StatusUpdates)
+	fmt.Printf("    Artifact Updates: %d\n", summary.ArtifactUpdates)
+
+	if summary.FinalMessage != nil {
+		fmt.Printf("  Final Message Parts: %d\n", len(summary.FinalMessage.Parts))
+	}
+
+	fmt.Printf("\n")
+}
+
+// printStreamingResult prints the end-of-stream summary in the given stream output
+func printStreamingResult(output streamOutput, summary streamingSummary, duration time.Duration) {
+	if output != streamOutputNDJSON {
+		printStreamingSummary(summary, duration)
+		return
+	}
+
+	printNDJSONLine(ndjsonStreamSummary{
+		Type:            "summary",
+		TaskID:          summary.TaskID,
+		ContextID:       summary.ContextID,
+		FinalStatus:     summary.FinalStatus,
+		DurationMs:      duration.Milliseconds(),
+		TotalEvents:     summary.TotalEvents,
+		StatusUpdates:   summary.StatusUpdates,
+		ArtifactUpdates: summary.ArtifactUpdates,
+	})
+}
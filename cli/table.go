@@ -0,0 +1,228 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	adk "github.com/inference-gateway/adk/types"
+)
+
+// Maximum message preview lengths in table and wide output
+const (
+	tablePreviewLength = 60
+	widePreviewLength  = 120
+)
+
+// tableView is the column layout of a command's output in table and wide formats
+type tableView struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// printFormattedTable outputs data in the configured format, rendering the table
+// view built by view for the table and wide formats
+func printFormattedTable(data any, view func(wide bool) tableView) error {
+	format, err := getOutputFormat()
+	if err != nil {
+		return err
+	}
+
+	if format != OutputFormatTable && format != OutputFormatWide {
+		return printFormatted(data)
+	}
+
+	fmt.Print(string(renderTable(view(format == OutputFormatWide))))
+	return nil
+}
+
+// rejectTableOutput fails when the table or wide format is selected. Commands that
+// change state on the server and have no table view call it before any request,
+// so that an unsupported format is not reported after the change was made.
+func rejectTableOutput() error {
+	format, err := getOutputFormat()
+	if err != nil {
+		return err
+	}
+	if format == OutputFormatTable || format == OutputFormatWide {
+		return fmt.Errorf("output format %q is not supported by this command", format)
+	}
+	return nil
+}
+
+// renderTable aligns the headers and rows of a table view into columns
+func renderTable(view tableView) []byte {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 3, ' ', 0)
+
+	_, _ = fmt.Fprintln(w, strings.Join(view.Headers, "\t"))
+	for _, row := range view.Rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			if cell == "" {
+				cell = "-"
+			}
+			cells[i] = cell
+		}
+		_, _ = fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+
+	_ = w.Flush()
+	return buf.Bytes()
+}
+
+// tasksTable lists tasks with their state, last update time and latest message
+func tasksTable(tasks []adk.Task, wide bool) tableView {
+	view := tableView{Headers: []string{"ID", "CONTEXT", "STATE", "UPDATED", "MESSAGE"}}
+	if wide {
+		view.Headers = append(view.Headers, "ARTIFACTS", "HISTORY")
+	}
+
+	for _, task := range tasks {
+		id, contextID, previewLength := shortID(task.ID), shortID(task.ContextID), tablePreviewLength
+		if wide {
+			id, contextID, previewLength = task.ID, task.ContextID, widePreviewLength
+		}
+
+		message := ""
+		if task.Status.Message != nil {
+			message = partsToText(task.Status.Message.Parts)
+		}
+		if message == "" {
+			message = latestAgentText(task.History)
+		}
+
+		row := []string{
+			id,
+			contextID,
+			humanState(task.Status.State),
+			formatTableTime(task.Status.Timestamp),
+			previewText(message, previewLength),
+		}
+		if wide {
+			row = append(row, fmt.Sprintf("%d", len(task.Artifacts)), fmt.Sprintf("%d", len(task.History)))
+		}
+		view.Rows = append(view.Rows, row)
+	}
+
+	return view
+}
+
+// historyTable lists the messages of every task in a context in conversation order
+func historyTable(tasks []adk.Task, wide bool) tableView {
+	view := tableView{Headers: []string{"TASK", "STATE", "ROLE", "MESSAGE"}}
+	if wide {
+		view.Headers = append(view.Headers, "MESSAGE ID", "PARTS")
+	}
+
+	for _, task := range tasks {
+		taskID, previewLength := shortID(task.ID), tablePreviewLength
+		if wide {
+			taskID, previewLength = task.ID, widePreviewLength
+		}
+		state := humanState(task.Status.State)
+
+		if len(task.History) == 0 {
+			row := []string{taskID, state, "", ""}
+			if wide {
+				row = append(row, "", "0")
+			}
+			view.Rows = append(view.Rows, row)
+			continue
+		}
+
+		for _, message := range task.History {
+			row := []string{
+				taskID,
+				state,
+				strings.ToLower(strings.TrimPrefix(string(message.Role), "ROLE_")),
+				previewText(partsToText(message.Parts), previewLength),
+			}
+			if wide {
+				row = append(row, message.MessageID, fmt.Sprintf("%d", len(message.Parts)))
+			}
+			view.Rows = append(view.Rows, row)
+		}
+	}
+
+	return view
+}
+
+// settingsTable lists configuration values with nested keys flattened to dotted paths
+func settingsTable(settings map[string]any) tableView {
+	view := tableView{Headers: []string{"KEY", "VALUE"}}
+
+	flat := map[string]string{}
+	flattenSettings("", settings, flat)
+	for _, key := range sortedKeys(flat) {
+		view.Rows = append(view.Rows, []string{key, flat[key]})
+	}
+
+	return view
+}
+
+// flattenSettings flattens nested settings maps into dotted keys
+func flattenSettings(prefix string, settings map[string]any, flat map[string]string) {
+	for key, value := range settings {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]any); ok {
+			flattenSettings(key, nested, flat)
+			continue
+		}
+		flat[key] = fmt.Sprint(value)
+	}
+}
+
+// skillsTable lists the skills advertised by an agent card
+func skillsTable(card *adk.AgentCard, wide bool) tableView {
+	view := tableView{Headers: []string{"ID", "NAME", "TAGS", "DESCRIPTION"}}
+	if wide {
+		view.Headers = append(view.Headers, "INPUT MODES", "OUTPUT MODES")
+	}
+
+	skills := append([]adk.AgentSkill(nil), card.Skills...)
+	sort.SliceStable(skills, func(i, j int) bool { return skills[i].ID < skills[j].ID })
+
+	for _, skill := range skills {
+		previewLength := tablePreviewLength
+		if wide {
+			previewLength = widePreviewLength
+		}
+
+		row := []string{
+			skill.ID,
+			skill.Name,
+			strings.Join(skill.Tags, ","),
+			previewText(skill.Description, previewLength),
+		}
+		if wide {
+			row = append(row, strings.Join(skill.InputModes, ","), strings.Join(skill.OutputModes, ","))
+		}
+		view.Rows = append(view.Rows, row)
+	}
+
+	return view
+}
+
+// previewText collapses whitespace and truncates text to at most maxLen runes
+func previewText(text string, maxLen int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	runes := []rune(text)
+	if len(runes) <= maxLen {
+		return text
+	}
+	return string(runes[:maxLen-1]) + "…"
+}
+
+// formatTableTime formats a timestamp for table output in local time
+func formatTableTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,205 @@
+package cli
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	client "github.com/inference-gateway/adk/client"
+	adk "github.com/inference-gateway/adk/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+func tableTestTasks() []adk.Task {
+	updated := time.Date(2025, 3, 4, 10, 30, 0, 0, time.Local)
+	reply := "The weather in Berlin is sunny\nwith a light breeze"
+	question := "What is the weather?"
+
+	return []adk.Task{
+		{
+			ID:        "0f3c9a1e-5b7d-4c2a-9e8f-1a2b3c4d5e6f",
+			ContextID: "c0ffee00-1111-2222-3333-444455556666",
+			Status: adk.TaskStatus{
+				State:     adk.TaskStateCompleted,
+				Timestamp: &updated,
+			},
+			History: []adk.Message{
+				{MessageID: "msg-1", Role: adk.RoleUser, Parts: []adk.Part{{Text: &question}}},
+				{MessageID: "msg-2", Role: adk.RoleAgent, Parts: []adk.Part{{Text: &reply}}},
+			},
+		},
+		{
+			ID:        "task-2",
+			ContextID: "c0ffee00-1111-2222-3333-444455556666",
+			Status:    adk.TaskStatus{State: adk.TaskStateWorking},
+		},
+	}
+}
+
+func TestTasksTable(t *testing.T) {
+	tasks := tableTestTasks()
+
+	view := tasksTable(tasks, false)
+	if len(view.Rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(view.Rows))
+	}
+
+	first := view.Rows[0]
+	if first[0] != "0f3c9a1e" || first[1] != "c0ffee00" {
+		t.Errorf("Expected short IDs, got %v", first[:2])
+	}
+	if first[2] != "completed" {
+		t.Errorf("Expected completed state, got %q", first[2])
+	}
+	if first[3] != "2025-03-04 10:30:00" {
+		t.Errorf("Expected formatted timestamp, got %q", first[3])
+	}
+	if first[4] != "The weather in Berlin is sunny with a light breeze" {
+		t.Errorf("Expected collapsed agent reply preview, got %q", first[4])
+	}
+
+	wide := tasksTable(tasks, true)
+	if len(wide.Headers) != 7 {
+		t.Errorf("Expected 7 wide columns, got %v", wide.Headers)
+	}
+	if wide.Rows[0][0] != tasks[0].ID || wide.Rows[0][6] != "2" {
+		t.Errorf("Expected full ID and history count in wide output, got %v", wide.Rows[0])
+	}
+}
+
+func TestHistoryTable(t *testing.T) {
+	view := historyTable(tableTestTasks(), false)
+
+	if len(view.Rows) != 3 {
+		t.Fatalf("Expected one row per message plus one for the empty task, got %d", len(view.Rows))
+	}
+	if view.Rows[0][2] != "user" || view.Rows[1][2] != "agent" {
+		t.Errorf("Expected user then agent roles, got %q and %q", view.Rows[0][2], view.Rows[1][2])
+	}
+}
+
+func TestRenderTable(t *testing.T) {
+	output := string(renderTable(tableView{
+		Headers: []string{"ID", "STATE"},
+		Rows: [][]string{
+			{"a", "working"},
+			{"longer-id", ""},
+		},
+	}))
+
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, got %q", output)
+	}
+
+	column := strings.Index(lines[0], "STATE")
+	for _, line := range lines[1:] {
+		if len(line) <= column || line[column-1] != ' ' || line[column] == ' ' {
+			t.Errorf("Expected column to be aligned at %d in %q", column, line)
+		}
+	}
+	if !strings.HasSuffix(lines[2], "-") {
+		t.Errorf("Expected empty cell to render as '-', got %q", lines[2])
+	}
+}
+
+func TestPrintFormattedTable(t *testing.T) {
+	defer viper.Set("output", "yaml")
+
+	settings := map[string]any{
+		"server-url": "http://localhost:8080",
+		"headers":    map[string]any{"x-tenant": "acme"},
+	}
+
+	viper.Set("output", "table")
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := printFormattedTable(settings, func(wide bool) tableView {
+		return settingsTable(settings)
+	})
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r)
+
+	if err != nil {
+		t.Fatalf("printFormattedTable failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "headers.x-tenant") {
+		t.Errorf("Expected flattened setting keys, got:\n%s", buf.String())
+	}
+
+	if err := printFormatted(settings); err == nil {
+		t.Error("Expected table output to be rejected by commands without a table view")
+	}
+
+	viper.Set("output", "xml")
+	if err := printFormattedTable(settings, func(wide bool) tableView { return tableView{} }); err == nil {
+		t.Error("Expected an error for an unknown output format")
+	}
+}
+
+func TestPreviewText(t *testing.T) {
+	if got := previewText("  hello \n world  ", 20); got != "hello world" {
+		t.Errorf("Expected collapsed whitespace, got %q", got)
+	}
+	if got := previewText("abcdefghij", 5); got != "abcd…" {
+		t.Errorf("Expected truncated text, got %q", got)
+	}
+}
+
+func TestRejectTableOutput_MutatingCommands(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.Error(w, "unexpected request", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	originalClient, originalHTTPClient := a2aClient, a2aHTTPClient
+	defer func() {
+		a2aClient, a2aHTTPClient = originalClient, originalHTTPClient
+		viper.Set("output", "yaml")
+		viper.Set("server-url", "")
+	}()
+	a2aClient = client.NewClientWithLogger(server.URL, zap.NewNop())
+	a2aHTTPClient = http.DefaultClient
+	viper.Set("server-url", server.URL)
+
+	cancelCmd := &cobra.Command{}
+	cancelCmd.Flags().String("all-in-context", "", "")
+	cancelCmd.Flags().String("state", "working", "")
+
+	submitCmd := &cobra.Command{}
+	submitCmd.Flags().String("context-id", "", "")
+	submitCmd.Flags().String("task-id", "", "")
+
+	commands := map[string]func() error{
+		"tasks cancel":       func() error { return cancelTaskCmd.RunE(cancelCmd, []string{"task-1"}) },
+		"tasks submit":       func() error { return submitTaskCmd.RunE(submitCmd, []string{"hello"}) },
+		"push-config delete": func() error { return pushConfigDeleteCmd.RunE(&cobra.Command{}, []string{"task-1"}) },
+		"rpc":                func() error { return rpcCmd.RunE(&cobra.Command{}, []string{"tasks/cancel"}) },
+	}
+	for _, format := range []string{"table", "wide"} {
+		viper.Set("output", format)
+		for name, run := range commands {
+			if err := run(); err == nil || !strings.Contains(err.Error(), "not supported by this command") {
+				t.Errorf("Expected %s -o %s to be rejected, got %v", name, format, err)
+			}
+		}
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("Expected no request to reach the server, got %d", n)
+	}
+}
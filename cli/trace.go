@@ -0,0 +1,555 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	viper "github.com/spf13/viper"
+	zap "go.uber.org/zap"
+)
+
+// maxTraceBodySize limits how much of a body is printed by --trace-http and stored in the HAR file
+const maxTraceBodySize = 1 << 20
+
+// httpTracer is an http.RoundTripper that prints the requests and responses it
+// forwards, including every line of SSE streams, and archives them to a HAR
+// file. Credential headers and the given secrets are redacted from both.
+type httpTracer struct {
+	base    http.RoundTripper
+	out     io.Writer
+	har     *harRecorder
+	secrets []string
+
+	mu  sync.Mutex
+	seq int
+}
+
+// httpTracerFromConfig returns a transport wrapper for the trace-http and har
+// settings, or nil when neither is set
+func httpTracerFromConfig(secrets []string) (func(http.RoundTripper) http.RoundTripper, error) {
+	var out io.Writer
+	if viper.GetBool("trace-http") {
+		out = os.Stderr
+	}
+
+	var har *harRecorder
+	if harPath := viper.GetString("har"); harPath != "" {
+		var err error
+		if har, err = newHARRecorder(harPath); err != nil {
+			return nil, err
+		}
+		flushHARArchive()
+		harArchive = har
+	}
+
+	if out == nil && har == nil {
+		return nil, nil
+	}
+	return func(base http.RoundTripper) http.RoundTripper {
+		return &httpTracer{base: base, out: out, har: har, secrets: secrets}
+	}, nil
+}
+
+func (t *httpTracer) RoundTrip(req *http.Request) (*http.Response, error) {
+	ex := &traceExchange{
+		tracer: t,
+		seq:    t.nextSeq(),
+		start:  time.Now(),
+		req:    req,
+	}
+
+	body, err := traceRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	ex.reqBody = body
+	t.printRequest(ex)
+
+	resp, err := t.base.RoundTrip(req)
+	ex.wait = time.Since(ex.start)
+	if err != nil {
+		t.printLines(ex.seq, "!", t.redact(err.Error()))
+		ex.finish(err)
+		return nil, err
+	}
+
+	ex.resp = resp
+	t.printResponseHead(ex)
+
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		resp.Body = &traceStream{body: resp.Body, ex: ex}
+		return resp, nil
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	ex.capture(respBody)
+	if len(respBody) > 0 {
+		t.printLines(ex.seq, "<", t.redact(traceBodyText(respBody)))
+	}
+	ex.finish(err)
+	return resp, err
+}
+
+func (t *httpTracer) nextSeq() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.seq++
+	return t.seq
+}
+
+// redact masks the configured secrets in a value
+func (t *httpTracer) redact(value string) string {
+	for _, secret := range t.secrets {
+		value = strings.ReplaceAll(value, secret, redactedValue)
+	}
+	return value
+}
+
+// redactedHeaderLines returns the headers as sorted "Name: value" lines with credentials masked
+func (t *httpTracer) redactedHeaderLines(headers http.Header) []string {
+	redacted := redactHeaders(headers)
+	lines := make([]string, 0, len(redacted))
+	for _, name := range sortedKeys(redacted) {
+		lines = append(lines, name+": "+t.redact(redacted[name]))
+	}
+	return lines
+}
+
+func (t *httpTracer) printRequest(ex *traceExchange) {
+	if t.out == nil {
+		return
+	}
+
+	req := ex.req
+	lines := []string{fmt.Sprintf("%s %s %s", req.Method, t.redact(req.URL.String()), req.Proto)}
+	lines = append(lines, t.redactedHeaderLines(req.Header)...)
+	if len(ex.reqBody) > 0 {
+		lines = append(lines, "", t.redact(traceBodyText(ex.reqBody)))
+	}
+	t.printLines(ex.seq, ">", strings.Join(lines, "\n"))
+}
+
+func (t *httpTracer) printResponseHead(ex *traceExchange) {
+	if t.out == nil {
+		return
+	}
+
+	resp := ex.resp
+	lines := []string{fmt.Sprintf("%s %s (%s)", resp.Proto, resp.Status, formatMs(durationMs(ex.wait)))}
+	lines = append(lines, t.redactedHeaderLines(resp.Header)...)
+	lines = append(lines, "")
+	t.printLines(ex.seq, "<", strings.Join(lines, "\n"))
+}
+
+// printLines writes text with every line prefixed by the exchange number and direction
+func (t *httpTracer) printLines(seq int, direction, text string) {
+	if t.out == nil {
+		return
+	}
+
+	var b strings.Builder
+	for _, line := range strings.Split(text, "\n") {
+		b.WriteString(strings.TrimRight(fmt.Sprintf("#%d %s %s", seq, direction, line), " "))
+		b.WriteByte('\n')
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, _ = io.WriteString(t.out, b.String())
+}
+
+// traceRequestBody returns the body of a request without consuming it
+func traceRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err == nil {
+			defer func() { _ = body.Close() }()
+			return io.ReadAll(body)
+		}
+	}
+
+	content, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(content))
+	return content, nil
+}
+
+// traceBodyText returns a body for printing, cut at maxTraceBodySize and without its final newline
+func traceBodyText(body []byte) string {
+	if len(body) > maxTraceBodySize {
+		return fmt.Sprintf("%s… (%d more bytes)", body[:maxTraceBodySize], len(body)-maxTraceBodySize)
+	}
+	return strings.TrimSuffix(string(body), "\n")
+}
+
+// traceExchange is a single request and response passing through the tracer
+type traceExchange struct {
+	tracer  *httpTracer
+	seq     int
+	start   time.Time
+	wait    time.Duration
+	req     *http.Request
+	reqBody []byte
+	resp    *http.Response
+
+	body      bytes.Buffer
+	bodySize  int
+	truncated bool
+	once      sync.Once
+}
+
+// capture stores response body content up to maxTraceBodySize
+func (ex *traceExchange) capture(content []byte) {
+	ex.bodySize += len(content)
+	if room := maxTraceBodySize - ex.body.Len(); room < len(content) {
+		content = content[:max(room, 0)]
+		ex.truncated = true
+	}
+	ex.body.Write(content)
+}
+
+// finish archives the exchange once its response is complete
+func (ex *traceExchange) finish(err error) {
+	ex.once.Do(func() {
+		if ex.tracer.har != nil {
+			ex.tracer.har.add(ex.harEntry(err))
+		}
+	})
+}
+
+// traceStream prints the lines of an SSE response body as the client reads them
+type traceStream struct {
+	body    io.ReadCloser
+	ex      *traceExchange
+	partial []byte
+	ended   bool
+}
+
+func (s *traceStream) Read(b []byte) (int, error) {
+	n, err := s.body.Read(b)
+	if n > 0 {
+		s.ex.capture(b[:n])
+		s.printLines(b[:n])
+	}
+	if err != nil {
+		s.end(err)
+	}
+	return n, err
+}
+
+func (s *traceStream) Close() error {
+	s.end(nil)
+	return s.body.Close()
+}
+
+// printLines prints every complete line received so far
+func (s *traceStream) printLines(chunk []byte) {
+	s.partial = append(s.partial, chunk...)
+	idx := bytes.LastIndexByte(s.partial, '\n')
+	if idx < 0 {
+		return
+	}
+	s.ex.tracer.printLines(s.ex.seq, "<", s.ex.tracer.redact(string(bytes.TrimRight(s.partial[:idx], "\r"))))
+	s.partial = append([]byte(nil), s.partial[idx+1:]...)
+}
+
+// end reports the end of the stream once
+func (s *traceStream) end(err error) {
+	if s.ended {
+		return
+	}
+	s.ended = true
+
+	tracer := s.ex.tracer
+	if len(s.partial) > 0 {
+		tracer.printLines(s.ex.seq, "<", tracer.redact(string(s.partial)))
+	}
+	elapsed := formatMs(durationMs(time.Since(s.ex.start)))
+	switch {
+	case err == nil || errors.Is(err, io.EOF):
+		tracer.printLines(s.ex.seq, "<", fmt.Sprintf("(stream closed after %s)", elapsed))
+		err = nil
+	default:
+		tracer.printLines(s.ex.seq, "!", tracer.redact(fmt.Sprintf("stream failed after %s: %v", elapsed, err)))
+	}
+	s.ex.finish(err)
+}
+
+// HTTP Archive 1.2 document written by --har
+type (
+	harDocument struct {
+		Log harLog `json:"log"`
+	}
+
+	harLog struct {
+		Version string     `json:"version"`
+		Creator harCreator `json:"creator"`
+		Entries []harEntry `json:"entries"`
+	}
+
+	harCreator struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+
+	harEntry struct {
+		StartedDateTime string      `json:"startedDateTime"`
+		Time            float64     `json:"time"`
+		Request         harRequest  `json:"request"`
+		Response        harResponse `json:"response"`
+		Cache           struct{}    `json:"cache"`
+		Timings         harTimings  `json:"timings"`
+		Error           string      `json:"_error,omitempty"`
+	}
+
+	harRequest struct {
+		Method      string         `json:"method"`
+		URL         string         `json:"url"`
+		HTTPVersion string         `json:"httpVersion"`
+		Cookies     []harNameValue `json:"cookies"`
+		Headers     []harNameValue `json:"headers"`
+		QueryString []harNameValue `json:"queryString"`
+		PostData    *harPostData   `json:"postData,omitempty"`
+		HeadersSize int            `json:"headersSize"`
+		BodySize    int            `json:"bodySize"`
+	}
+
+	harResponse struct {
+		Status      int            `json:"status"`
+		StatusText  string         `json:"statusText"`
+		HTTPVersion string         `json:"httpVersion"`
+		Cookies     []harNameValue `json:"cookies"`
+		Headers     []harNameValue `json:"headers"`
+		Content     harContent     `json:"content"`
+		RedirectURL string         `json:"redirectURL"`
+		HeadersSize int            `json:"headersSize"`
+		BodySize    int            `json:"bodySize"`
+	}
+
+	harNameValue struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+
+	harPostData struct {
+		MimeType string `json:"mimeType"`
+		Text     string `json:"text"`
+	}
+
+	harContent struct {
+		Size     int    `json:"size"`
+		MimeType string `json:"mimeType"`
+		Text     string `json:"text,omitempty"`
+		Comment  string `json:"comment,omitempty"`
+	}
+
+	harTimings struct {
+		Send    float64 `json:"send"`
+		Wait    float64 `json:"wait"`
+		Receive float64 `json:"receive"`
+	}
+)
+
+// harEntry converts the exchange into a HAR entry with credentials redacted
+func (ex *traceExchange) harEntry(err error) harEntry {
+	tracer := ex.tracer
+	total := time.Since(ex.start)
+
+	headers := func(h http.Header) []harNameValue {
+		values := []harNameValue{}
+		redacted := redactHeaders(h)
+		for _, name := range sortedKeys(redacted) {
+			values = append(values, harNameValue{Name: name, Value: tracer.redact(redacted[name])})
+		}
+		return values
+	}
+
+	query := []harNameValue{}
+	for _, name := range sortedKeys(ex.req.URL.Query()) {
+		for _, value := range ex.req.URL.Query()[name] {
+			query = append(query, harNameValue{Name: name, Value: tracer.redact(value)})
+		}
+	}
+
+	entry := harEntry{
+		StartedDateTime: ex.start.Format(time.RFC3339Nano),
+		Time:            durationMs(total),
+		Request: harRequest{
+			Method:      ex.req.Method,
+			URL:         tracer.redact(ex.req.URL.String()),
+			HTTPVersion: ex.req.Proto,
+			Cookies:     []harNameValue{},
+			Headers:     headers(ex.req.Header),
+			QueryString: query,
+			HeadersSize: -1,
+			BodySize:    len(ex.reqBody),
+		},
+		Response: harResponse{
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []harNameValue{},
+			Headers:     []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    -1,
+		},
+		Timings: harTimings{
+			Send:    0,
+			Wait:    durationMs(ex.wait),
+			Receive: durationMs(total - ex.wait),
+		},
+	}
+
+	if len(ex.reqBody) > 0 {
+		entry.Request.PostData = &harPostData{
+			MimeType: ex.req.Header.Get("Content-Type"),
+			Text:     tracer.redact(traceBodyText(ex.reqBody)),
+		}
+	}
+
+	if ex.resp != nil {
+		entry.Response.Status = ex.resp.StatusCode
+		entry.Response.StatusText = http.StatusText(ex.resp.StatusCode)
+		entry.Response.HTTPVersion = ex.resp.Proto
+		entry.Response.Headers = headers(ex.resp.Header)
+		entry.Response.BodySize = ex.bodySize
+		entry.Response.Content = harContent{
+			Size:     ex.bodySize,
+			MimeType: ex.resp.Header.Get("Content-Type"),
+			Text:     tracer.redact(ex.body.String()),
+		}
+		if ex.truncated {
+			entry.Response.Content.Comment = fmt.Sprintf("truncated to the first %d bytes", maxTraceBodySize)
+		}
+	}
+	if err != nil {
+		entry.Error = tracer.redact(err.Error())
+	}
+	return entry
+}
+
+// harFlushInterval is how long new HAR entries may wait before the archive is
+// rewritten, so busy commands rewrite it at most once per interval
+var harFlushInterval = time.Second
+
+// harArchive is the recorder of the --har file, flushed when the command exits
+var harArchive *harRecorder
+
+// harRecorder collects HAR entries and rewrites the archive shortly after new ones
+// arrive, so the file stays valid when the command is interrupted
+type harRecorder struct {
+	path string
+
+	mu      sync.Mutex
+	doc     harDocument
+	pending *time.Timer
+
+	// writeMu serializes the rewrites of the archive
+	writeMu sync.Mutex
+}
+
+// newHARRecorder creates the archive at path with no entries
+func newHARRecorder(path string) (*harRecorder, error) {
+	version := appVersion
+	if version == "" {
+		version = "dev"
+	}
+
+	r := &harRecorder{
+		path: path,
+		doc: harDocument{Log: harLog{
+			Version: "1.2",
+			Creator: harCreator{Name: "a2a-debugger", Version: version},
+			Entries: []harEntry{},
+		}},
+	}
+	if err := r.flush(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// add appends an entry and schedules a rewrite of the archive
+func (r *harRecorder) add(entry harEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.doc.Log.Entries = append(r.doc.Log.Entries, entry)
+	if r.pending == nil {
+		r.pending = time.AfterFunc(harFlushInterval, func() {
+			if err := r.flush(); err != nil {
+				logger.Warn("Failed to write HAR file", zap.String("path", r.path), zap.Error(err))
+			}
+		})
+	}
+}
+
+// flush rewrites the archive with the entries added so far. Entries are encoded
+// outside of the lock, so requests are not held up while the archive is written.
+func (r *harRecorder) flush() error {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+
+	r.mu.Lock()
+	if r.pending != nil {
+		r.pending.Stop()
+		r.pending = nil
+	}
+	doc := r.doc
+	r.mu.Unlock()
+
+	return writeHARFile(r.path, doc)
+}
+
+// flushHARArchive writes the pending entries of the --har file before the command exits
+func flushHARArchive() {
+	if harArchive == nil {
+		return
+	}
+	if err := harArchive.flush(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write HAR file: %v\n", err)
+	}
+}
+
+// writeHARFile replaces the archive at path atomically
+func writeHARFile(path string, doc harDocument) error {
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode HAR file: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".har-*")
+	if err != nil {
+		return fmt.Errorf("failed to write HAR file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write HAR file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write HAR file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write HAR file: %w", err)
+	}
+	return nil
+}
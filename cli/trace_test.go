@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	client "github.com/inference-gateway/adk/client"
+	adk "github.com/inference-gateway/adk/types"
+	"go.uber.org/zap"
+)
+
+func readHAR(t *testing.T, path string) harDocument {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read HAR file: %v", err)
+	}
+	var doc harDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("Expected a valid HAR file, got %v", err)
+	}
+	return doc
+}
+
+func TestHTTPTracer(t *testing.T) {
+	harPath := filepath.Join(t.TempDir(), "trace.har")
+	har, err := newHARRecorder(harPath)
+	if err != nil {
+		t.Fatalf("newHARRecorder failed: %v", err)
+	}
+	if doc := readHAR(t, harPath); doc.Log.Version != "1.2" || len(doc.Log.Entries) != 0 {
+		t.Fatalf("Expected an empty archive to be created, got %+v", doc.Log)
+	}
+
+	headers := http.Header{"Authorization": []string{"Bearer s3cr3t-token"}}
+	var out bytes.Buffer
+	tracer := &httpTracer{base: http.DefaultTransport, out: &out, har: har, secrets: headerSecrets(headers)}
+
+	a2a := client.NewClientWithLogger(newTestUpstream(t, testMockScenario), zap.NewNop())
+	a2a.SetHTTPClient(&http.Client{Transport: &authTransport{base: tracer, headers: headers}})
+	ctx := context.Background()
+
+	text := "weather with s3cr3t-token"
+	message := adk.MessageSendParams{Message: adk.Message{MessageID: "m1", Role: adk.RoleUser, Parts: []adk.Part{{Text: &text}}}}
+	if _, err := a2a.SendTask(ctx, message); err != nil {
+		t.Fatalf("SendTask failed: %v", err)
+	}
+	events, err := a2a.SendTaskStreaming(ctx, message)
+	if err != nil {
+		t.Fatalf("SendTaskStreaming failed: %v", err)
+	}
+	for range events {
+	}
+
+	trace := out.String()
+	for _, expected := range []string{
+		"#1 > POST ",
+		"#1 > Authorization: [REDACTED]",
+		`#1 > {"jsonrpc":"2.0","method":"message/send"`,
+		"#1 < HTTP/1.1 200 OK",
+		"#1 < Content-Type: application/json",
+		`#1 < {"id":`,
+		"#2 < Content-Type: text/event-stream",
+		`#2 < data: {"id":`,
+		"#2 < (stream closed after ",
+	} {
+		if !strings.Contains(trace, expected) {
+			t.Errorf("Expected the trace to contain %q, got:\n%s", expected, trace)
+		}
+	}
+	if strings.Contains(trace, "s3cr3t-token") {
+		t.Errorf("Expected the token to be redacted, got:\n%s", trace)
+	}
+
+	if err := har.flush(); err != nil {
+		t.Fatalf("flush failed: %v", err)
+	}
+	raw, _ := os.ReadFile(harPath)
+	if strings.Contains(string(raw), "s3cr3t-token") {
+		t.Error("Expected the token to be redacted from the HAR file")
+	}
+
+	doc := readHAR(t, harPath)
+	if len(doc.Log.Entries) != 2 {
+		t.Fatalf("Expected two archived exchanges, got %d", len(doc.Log.Entries))
+	}
+	send, stream := doc.Log.Entries[0], doc.Log.Entries[1]
+	if send.Request.Method != http.MethodPost || send.Response.Status != http.StatusOK || send.Request.PostData == nil || !strings.Contains(send.Request.PostData.Text, "message/send") {
+		t.Errorf("Unexpected message/send entry: %+v", send)
+	}
+	if stream.Response.Content.MimeType != "text/event-stream" || strings.Count(stream.Response.Content.Text, "data:") != 4 {
+		t.Errorf("Expected the whole stream to be archived, got %q", stream.Response.Content.Text)
+	}
+	if stream.Response.Content.Size == 0 || stream.Timings.Receive <= 0 {
+		t.Errorf("Unexpected size or timings: %+v / %+v", stream.Response.Content, stream.Timings)
+	}
+}
+
+func TestHTTPTracer_TransportError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	serverURL := server.URL
+	server.Close()
+
+	harPath := filepath.Join(t.TempDir(), "trace.har")
+	har, err := newHARRecorder(harPath)
+	if err != nil {
+		t.Fatalf("newHARRecorder failed: %v", err)
+	}
+	var out bytes.Buffer
+	tracer := &httpTracer{base: http.DefaultTransport, out: &out, har: har}
+
+	req, _ := http.NewRequest(http.MethodGet, serverURL+"/.well-known/agent-card.json", nil)
+	if _, err := tracer.RoundTrip(req); err == nil {
+		t.Fatal("Expected a connection error")
+	}
+
+	if !strings.Contains(out.String(), "#1 > GET "+serverURL) || !strings.Contains(out.String(), "#1 ! ") {
+		t.Errorf("Expected the request and the failure to be traced, got:\n%s", out.String())
+	}
+	if err := har.flush(); err != nil {
+		t.Fatalf("flush failed: %v", err)
+	}
+	doc := readHAR(t, harPath)
+	if len(doc.Log.Entries) != 1 || doc.Log.Entries[0].Response.Status != 0 || doc.Log.Entries[0].Error == "" {
+		t.Errorf("Expected the failed exchange to be archived with its error, got %+v", doc.Log.Entries)
+	}
+}
+
+func TestNewHARRecorder_InvalidPath(t *testing.T) {
+	if _, err := newHARRecorder(filepath.Join(t.TempDir(), "missing", "trace.har")); err == nil {
+		t.Error("Expected an error for a directory that does not exist")
+	}
+}
+
+func TestHARRecorder_BatchesWrites(t *testing.T) {
+	originalInterval := harFlushInterval
+	defer func() { harFlushInterval = originalInterval }()
+	harFlushInterval = 50 * time.Millisecond
+
+	harPath := filepath.Join(t.TempDir(), "trace.har")
+	har, err := newHARRecorder(harPath)
+	if err != nil {
+		t.Fatalf("newHARRecorder failed: %v", err)
+	}
+
+	for i := 0; i < 100; i++ {
+		har.add(harEntry{Request: harRequest{Method: http.MethodPost}})
+	}
+	if doc := readHAR(t, harPath); len(doc.Log.Entries) != 0 {
+		t.Fatalf("Expected the archive not to be rewritten for every entry, got %d entries", len(doc.Log.Entries))
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(readHAR(t, harPath).Log.Entries) != 100 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the pending entries to be written after the flush interval")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	viper "github.com/spf13/viper"
+)
+
+// buildTLSConfig builds the TLS configuration for connections to the A2A server
+// from the insecure, ca-cert, client-cert, client-key and tls-server-name settings
+func buildTLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: viper.GetBool("insecure"), //nolint:gosec // explicitly requested by the user
+		ServerName:         viper.GetString("tls-server-name"),
+	}
+
+	if caCert := viper.GetString("ca-cert"); caCert != "" {
+		pem, err := os.ReadFile(caCert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no valid certificates found in %s", caCert)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	clientCert := viper.GetString("client-cert")
+	clientKey := viper.GetString("client-key")
+	switch {
+	case clientCert != "" && clientKey != "":
+		cert, err := tls.LoadX509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	case clientCert != "" || clientKey != "":
+		return nil, fmt.Errorf("--client-cert and --client-key must be provided together")
+	}
+
+	return tlsConfig, nil
+}
+
+// newHTTPClient builds the HTTP client used for every request to the A2A server.
+// The given headers are attached to each request, including agent card fetches.
+// The wrappers are applied to the transport in order, below the headers, so they
+// see requests as they are sent.
+func newHTTPClient(timeout time.Duration, headers http.Header, wrappers ...func(http.RoundTripper) http.RoundTripper) (*http.Client, error) {
+	tlsConfig, err := buildTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	var roundTripper http.RoundTripper = transport
+	for _, wrap := range wrappers {
+		roundTripper = wrap(roundTripper)
+	}
+	if len(headers) > 0 {
+		roundTripper = &authTransport{base: roundTripper, headers: headers}
+	}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: roundTripper,
+	}, nil
+}
@@ -0,0 +1,211 @@
+package cli
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// resetTLSSettings clears every TLS related viper key
+func resetTLSSettings() {
+	for _, key := range []string{"insecure", "ca-cert", "client-cert", "client-key", "tls-server-name"} {
+		viper.Set(key, nil)
+	}
+}
+
+// writeClientCertificate generates a self-signed client certificate and key and
+// returns their paths along with the certificate for server-side verification
+func writeClientCertificate(t *testing.T, dir string) (string, string, *x509.Certificate) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "a2a-debugger-test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+
+	certPath := filepath.Join(dir, "client.crt")
+	keyPath := filepath.Join(dir, "client.key")
+	if err := os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("failed to write certificate: %v", err)
+	}
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+
+	return certPath, keyPath, cert
+}
+
+func TestNewHTTPClient_ServerVerification(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	caPath := filepath.Join(dir, "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caPath, caPEM, 0o600); err != nil {
+		t.Fatalf("failed to write CA bundle: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		settings    map[string]any
+		expectError bool
+	}{
+		{
+			name:        "Untrusted certificate is rejected",
+			settings:    map[string]any{},
+			expectError: true,
+		},
+		{
+			name:     "Insecure skips verification",
+			settings: map[string]any{"insecure": true},
+		},
+		{
+			name:     "Custom CA bundle",
+			settings: map[string]any{"ca-cert": caPath},
+		},
+		{
+			name:        "Custom CA bundle with mismatching server name",
+			settings:    map[string]any{"ca-cert": caPath, "tls-server-name": "other.invalid"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetTLSSettings()
+			defer resetTLSSettings()
+			for k, v := range tt.settings {
+				viper.Set(k, v)
+			}
+
+			httpClient, err := newHTTPClient(5*time.Second, nil)
+			if err != nil {
+				t.Fatalf("newHTTPClient failed: %v", err)
+			}
+
+			resp, err := httpClient.Get(server.URL)
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+			if tt.expectError && err == nil {
+				t.Error("Expected TLS error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestNewHTTPClient_MutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath, clientCert := writeClientCertificate(t, dir)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	resetTLSSettings()
+	defer resetTLSSettings()
+	viper.Set("insecure", true)
+
+	withoutCert, err := newHTTPClient(5*time.Second, nil)
+	if err != nil {
+		t.Fatalf("newHTTPClient failed: %v", err)
+	}
+	if resp, err := withoutCert.Get(server.URL); err == nil {
+		_ = resp.Body.Close()
+		t.Error("Expected handshake failure without a client certificate")
+	}
+
+	viper.Set("client-cert", certPath)
+	viper.Set("client-key", keyPath)
+
+	withCert, err := newHTTPClient(5*time.Second, nil)
+	if err != nil {
+		t.Fatalf("newHTTPClient failed: %v", err)
+	}
+	resp, err := withCert.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Expected mutual TLS request to succeed, got: %v", err)
+	}
+	_ = resp.Body.Close()
+}
+
+func TestBuildTLSConfig_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings map[string]any
+	}{
+		{
+			name:     "Missing CA bundle",
+			settings: map[string]any{"ca-cert": "/nonexistent/ca.pem"},
+		},
+		{
+			name:     "Client certificate without key",
+			settings: map[string]any{"client-cert": "/tmp/client.crt"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetTLSSettings()
+			defer resetTLSSettings()
+			for k, v := range tt.settings {
+				viper.Set(k, v)
+			}
+
+			if _, err := buildTLSConfig(); err == nil {
+				t.Error("Expected error but got none")
+			}
+		})
+	}
+}
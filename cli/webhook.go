@@ -0,0 +1,324 @@
+package cli
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	cobra "github.com/spf13/cobra"
+	zap "go.uber.org/zap"
+
+	adk "github.com/inference-gateway/adk/types"
+)
+
+// maxWebhookBodySize caps the size of a single push notification payload
+const maxWebhookBodySize = 10 << 20
+
+// notificationTokenHeader is the header A2A servers use to echo the configured push token
+const notificationTokenHeader = "X-A2A-Notification-Token"
+
+// Webhook namespace command
+var webhookCmd = &cobra.Command{
+	Use:   "webhook",
+	Short: "Push notification webhook commands",
+	Long:  "Commands for receiving and inspecting A2A push notifications locally.",
+}
+
+var webhookListenCmd = &cobra.Command{
+	Use:   "listen",
+	Short: "Start a local webhook receiver for push notifications",
+	Long: `Starts a local HTTP server that receives A2A push notifications and prints
+each one using the same rendering as submit-streaming.
+
+Use --notification-token and/or --jwt-secret to reject notifications that do not carry the
+expected credentials. With --task-id the receiver registers itself as the push
+notification config of that task on startup and deletes the config on exit.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addr, _ := cmd.Flags().GetString("addr")
+		path, _ := cmd.Flags().GetString("path")
+		token, _ := cmd.Flags().GetString("notification-token")
+		jwtSecret, _ := cmd.Flags().GetString("jwt-secret")
+		showRaw, _ := cmd.Flags().GetBool("raw")
+		taskID, _ := cmd.Flags().GetString("task-id")
+		publicURL, _ := cmd.Flags().GetString("public-url")
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		receiver := &webhookReceiver{
+			token:     token,
+			jwtSecret: []byte(jwtSecret),
+			showRaw:   showRaw,
+		}
+
+		mux := http.NewServeMux()
+		mux.Handle(path, receiver)
+
+		listener, err := net.Listen("tcp", addr)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", addr, err)
+		}
+
+		server := &http.Server{
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		serverErr := make(chan error, 1)
+		go func() {
+			serverErr <- server.Serve(listener)
+		}()
+
+		if publicURL == "" {
+			publicURL = webhookURLForListener(listener.Addr(), path)
+		}
+
+		fmt.Printf("👂 Listening for push notifications on %s\n", publicURL)
+
+		if taskID != "" {
+			ensureA2AClient()
+
+			config, err := buildPushNotificationConfig(publicURL, "", token, nil, "")
+			if err != nil {
+				return err
+			}
+
+			_, err = a2aClient.SetTaskPushNotificationConfig(ctx, adk.TaskPushNotificationConfig{
+				Name:                   taskID,
+				PushNotificationConfig: config,
+			})
+			if err != nil {
+				_ = server.Close()
+				return handleA2AError(err, "tasks/pushNotificationConfig/set")
+			}
+			fmt.Printf("✅ Registered webhook for task %s\n", taskID)
+
+			defer func() {
+				cleanupCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				defer cancel()
+
+				_, err := a2aClient.DeleteTaskPushNotificationConfig(cleanupCtx, adk.DeleteTaskPushNotificationConfigParams{Name: taskID})
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "⚠️  Failed to delete push notification config for task %s: %v\n", taskID, handleA2AError(err, "tasks/pushNotificationConfig/delete"))
+					return
+				}
+				fmt.Printf("🧹 Deleted webhook registration for task %s\n", taskID)
+			}()
+		}
+
+		fmt.Printf("\nPress Ctrl+C to stop.\n\n")
+
+		select {
+		case err := <-serverErr:
+			if !errors.Is(err, http.ErrServerClosed) {
+				return fmt.Errorf("webhook server failed: %w", err)
+			}
+		case <-ctx.Done():
+		}
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logger.Warn("Failed to shut down webhook server", zap.Error(err))
+		}
+
+		fmt.Printf("\n📋 Received %d notification(s)\n", receiver.count())
+		return nil
+	},
+}
+
+// webhookReceiver is the HTTP handler that validates and prints push notifications
+type webhookReceiver struct {
+	token     string
+	jwtSecret []byte
+	showRaw   bool
+
+	mu       sync.Mutex
+	received int
+}
+
+func (rcv *webhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := rcv.authorize(r); err != nil {
+		logger.Debug("Rejected push notification", zap.String("remote_addr", r.RemoteAddr), zap.Error(err))
+		fmt.Printf("🚫 Rejected notification from %s: %v\n\n", r.RemoteAddr, err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBodySize))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	event, err := decodeNotification(body)
+	if err != nil {
+		logger.Debug("Failed to decode push notification", zap.Error(err))
+		fmt.Printf("⚠️  Undecodable notification from %s: %v\n\n", r.RemoteAddr, err)
+		http.Error(w, "invalid notification payload", http.StatusBadRequest)
+		return
+	}
+
+	rcv.mu.Lock()
+	defer rcv.mu.Unlock()
+
+	rcv.received++
+	fmt.Printf("🔔 Notification #%d from %s at %s\n", rcv.received, r.RemoteAddr, time.Now().Format(time.RFC3339))
+
+	if rcv.showRaw {
+		printRawStreamEvent(adk.JSONRPCSuccessResponse{Result: event})
+	} else {
+		eventJSON, eventKind, err := classifyStreamEvent(event)
+		if err != nil {
+			logger.Error("Failed to classify notification", zap.Error(err))
+		} else {
+			printStreamEvent(eventKind, eventJSON)
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// count returns the number of notifications accepted so far
+func (rcv *webhookReceiver) count() int {
+	rcv.mu.Lock()
+	defer rcv.mu.Unlock()
+	return rcv.received
+}
+
+// authorize accepts a request when no credentials are configured, or when it
+// carries the configured token or a JWT signed with the configured secret
+func (rcv *webhookReceiver) authorize(r *http.Request) error {
+	if rcv.token == "" && len(rcv.jwtSecret) == 0 {
+		return nil
+	}
+
+	bearer := ""
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		bearer = strings.TrimPrefix(auth, "Bearer ")
+	}
+
+	if rcv.token != "" {
+		for _, candidate := range []string{r.Header.Get(notificationTokenHeader), bearer} {
+			if candidate != "" && subtle.ConstantTimeCompare([]byte(candidate), []byte(rcv.token)) == 1 {
+				return nil
+			}
+		}
+	}
+
+	if len(rcv.jwtSecret) > 0 && bearer != "" {
+		err := validateHS256JWT(bearer, rcv.jwtSecret, time.Now())
+		if err == nil {
+			return nil
+		}
+		return fmt.Errorf("invalid JWT: %w", err)
+	}
+
+	return fmt.Errorf("missing or invalid notification token")
+}
+
+// decodeNotification decodes a push notification body. Payloads wrapped in a
+// task update envelope ({"type": ..., "task": {...}}) are unwrapped to the task.
+func decodeNotification(body []byte) (map[string]any, error) {
+	var payload map[string]any
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal notification: %w", err)
+	}
+
+	if task, ok := payload["task"].(map[string]any); ok {
+		if _, isTask := payload["id"]; !isTask {
+			return task, nil
+		}
+	}
+
+	return payload, nil
+}
+
+// validateHS256JWT verifies the signature and the time-based claims of an HS256 JWT
+func validateHS256JWT(token string, secret []byte, now time.Time) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("malformed token")
+	}
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return fmt.Errorf("malformed header: %w", err)
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return fmt.Errorf("malformed header: %w", err)
+	}
+	if header.Alg != "HS256" {
+		return fmt.Errorf("unsupported algorithm %q", header.Alg)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return fmt.Errorf("malformed signature: %w", err)
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return fmt.Errorf("signature mismatch")
+	}
+
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return fmt.Errorf("malformed claims: %w", err)
+	}
+
+	var claims struct {
+		ExpiresAt *float64 `json:"exp"`
+		NotBefore *float64 `json:"nbf"`
+	}
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		return fmt.Errorf("malformed claims: %w", err)
+	}
+
+	if claims.ExpiresAt != nil && now.Unix() >= int64(*claims.ExpiresAt) {
+		return fmt.Errorf("token expired")
+	}
+	if claims.NotBefore != nil && now.Unix() < int64(*claims.NotBefore) {
+		return fmt.Errorf("token not valid yet")
+	}
+
+	return nil
+}
+
+// webhookURLForListener builds the URL an agent on this machine can use to reach the listener
+func webhookURLForListener(addr net.Addr, path string) string {
+	host := "localhost"
+	port := ""
+	if tcpAddr, ok := addr.(*net.TCPAddr); ok {
+		if !tcpAddr.IP.IsUnspecified() {
+			host = tcpAddr.IP.String()
+		}
+		port = fmt.Sprintf("%d", tcpAddr.Port)
+	}
+	return "http://" + net.JoinHostPort(host, port) + path
+}
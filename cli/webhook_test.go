@@ -0,0 +1,168 @@
+package cli
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	adk "github.com/inference-gateway/adk/types"
+	"go.uber.org/zap"
+)
+
+// signHS256 builds a JWT with the given claims signed using secret
+func signHS256(claims string, secret string) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(claims))
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(header + "." + payload))
+	return header + "." + payload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestValidateHS256JWT(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name        string
+		token       string
+		expectError bool
+	}{
+		{
+			name:  "Valid token",
+			token: signHS256(`{"sub":"agent","exp":1700000600}`, "secret"),
+		},
+		{
+			name:        "Wrong secret",
+			token:       signHS256(`{"sub":"agent"}`, "other"),
+			expectError: true,
+		},
+		{
+			name:        "Expired token",
+			token:       signHS256(`{"exp":1699999999}`, "secret"),
+			expectError: true,
+		},
+		{
+			name:        "Not valid yet",
+			token:       signHS256(`{"nbf":1700000600}`, "secret"),
+			expectError: true,
+		},
+		{
+			name:        "Malformed token",
+			token:       "not-a-jwt",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateHS256JWT(tt.token, []byte("secret"), now)
+			if tt.expectError && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestWebhookReceiver(t *testing.T) {
+	originalLogger := logger
+	logger = zap.NewNop()
+	defer func() { logger = originalLogger }()
+
+	notification := `{"type":"task_update","taskId":"task-1","state":"TASK_STATE_COMPLETED","task":{"id":"task-1","contextId":"ctx-1","status":{"state":"` + string(adk.TaskStateCompleted) + `"}}}`
+	statusEvent := `{"taskId":"task-2","contextId":"ctx-1","final":true,"status":{"state":"` + string(adk.TaskStateWorking) + `"}}`
+
+	tests := []struct {
+		name           string
+		token          string
+		jwtSecret      string
+		headers        map[string]string
+		body           string
+		expectedStatus int
+		expectedOutput string
+	}{
+		{
+			name:           "Task update without credentials",
+			body:           notification,
+			expectedStatus: http.StatusOK,
+			expectedOutput: "Task Snapshot: task-1",
+		},
+		{
+			name:           "Status event with bearer token",
+			token:          "secret",
+			headers:        map[string]string{"Authorization": "Bearer secret"},
+			body:           statusEvent,
+			expectedStatus: http.StatusOK,
+			expectedOutput: "Status Update: " + string(adk.TaskStateWorking),
+		},
+		{
+			name:           "Notification token header",
+			token:          "secret",
+			headers:        map[string]string{notificationTokenHeader: "secret"},
+			body:           notification,
+			expectedStatus: http.StatusOK,
+			expectedOutput: "Task Snapshot: task-1",
+		},
+		{
+			name:           "Wrong token",
+			token:          "secret",
+			headers:        map[string]string{"Authorization": "Bearer wrong"},
+			body:           notification,
+			expectedStatus: http.StatusUnauthorized,
+			expectedOutput: "Rejected notification",
+		},
+		{
+			name:           "Valid JWT",
+			jwtSecret:      "jwt-secret",
+			headers:        map[string]string{"Authorization": "Bearer " + signHS256(`{"sub":"agent"}`, "jwt-secret")},
+			body:           notification,
+			expectedStatus: http.StatusOK,
+			expectedOutput: "Task Snapshot: task-1",
+		},
+		{
+			name:           "Invalid payload",
+			body:           "not json",
+			expectedStatus: http.StatusBadRequest,
+			expectedOutput: "Undecodable notification",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receiver := &webhookReceiver{token: tt.token, jwtSecret: []byte(tt.jwtSecret)}
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			receiver.ServeHTTP(rec, req)
+
+			_ = w.Close()
+			os.Stdout = oldStdout
+			var buf bytes.Buffer
+			_, _ = buf.ReadFrom(r)
+			output := buf.String()
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+			if !strings.Contains(output, tt.expectedOutput) {
+				t.Errorf("Expected output to contain '%s', but it didn't.\nActual output:\n%s", tt.expectedOutput, output)
+			}
+		})
+	}
+}